@@ -0,0 +1,121 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/lintcache"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheCleanAllFlagVal bool
+
+	cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the golangci-lint cache used by the plugin",
+	}
+
+	cacheStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Print the location, size and number of entries of the golangci-lint cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := golangCILintCache.Status()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			_, _ = fmt.Fprintf(out, "Cache:         %s\n", golangCILintCache.Dir)
+			_, _ = fmt.Fprintf(out, "golangci-lint: %s (%s)\n", loadedAssetInfo.GolangCILintVersion, loadedAssetInfo.GolangCILintAssetPath)
+			_, _ = fmt.Fprintf(out, "Size:          %s\n", lintcache.FormatSize(status.Size))
+			_, _ = fmt.Fprintf(out, "Entries:       %d\n", status.Entries)
+			if golangCILintCacheRootDir == "" {
+				_, _ = fmt.Fprintf(out, "The cache is specified by the %s environment variable\n", lintcache.EnvVar)
+				return nil
+			}
+
+			otherCaches, err := otherGolangCILintCaches()
+			if err != nil {
+				return err
+			}
+			if len(otherCaches) == 0 {
+				return nil
+			}
+			_, _ = fmt.Fprintf(out, "Caches of other golangci-lint executables in %s (remove them using \"cache clean --all\"):\n", golangCILintCacheRootDir)
+			for _, cache := range otherCaches {
+				status, err := cache.Status()
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(out, "  %s: %s (%d entries)\n", filepath.Base(cache.Dir), lintcache.FormatSize(status.Size), status.Entries)
+			}
+			return nil
+		},
+	}
+
+	cacheCleanCmd = &cobra.Command{
+		Use:   "clean",
+		Short: "Remove the golangci-lint cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			caches := []lintcache.Cache{golangCILintCache}
+			if cacheCleanAllFlagVal {
+				otherCaches, err := otherGolangCILintCaches()
+				if err != nil {
+					return err
+				}
+				caches = append(caches, otherCaches...)
+			}
+			for _, cache := range caches {
+				status, err := cache.Status()
+				if err != nil {
+					return err
+				}
+				if err := cache.Clean(cmd.Context()); err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Removed %s (%s)\n", cache.Dir, lintcache.FormatSize(status.Size))
+			}
+			return nil
+		},
+	}
+)
+
+// otherGolangCILintCaches returns the caches in the cache root directory other than the one used by the plugin.
+func otherGolangCILintCaches() ([]lintcache.Cache, error) {
+	if golangCILintCacheRootDir == "" {
+		return nil, nil
+	}
+	caches, err := lintcache.List(golangCILintCacheRootDir)
+	if err != nil {
+		return nil, err
+	}
+	var otherCaches []lintcache.Cache
+	for _, cache := range caches {
+		if cache.Dir != golangCILintCache.Dir {
+			otherCaches = append(otherCaches, cache)
+		}
+	}
+	return otherCaches, nil
+}
+
+func init() {
+	cacheCleanCmd.Flags().BoolVar(&cacheCleanAllFlagVal, "all", false, "Also remove the caches of other golangci-lint executables (for example, caches left behind by previous versions of the golangci-lint asset)")
+
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
@@ -16,20 +16,45 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 var (
+	provenanceFlagVal bool
+	writeFlagVal      string
+
 	configCmd = &cobra.Command{
 		Use:   "config",
 		Short: "Prints the configuration used by the golangci-lint plugin",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if provenanceFlagVal && writeFlagVal != "" {
+				return errors.New("--provenance and --write cannot both be specified")
+			}
+			if provenanceFlagVal {
+				for _, path := range configProvenance.Paths() {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", path, configProvenance[path])
+				}
+				return nil
+			}
+			if writeFlagVal != "" {
+				if err := os.WriteFile(writeFlagVal, assetRunner.Config(), 0644); err != nil {
+					return errors.Wrapf(err, "failed to write configuration to %s", writeFlagVal)
+				}
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Wrote golangci-lint configuration to %s\n", writeFlagVal)
+				return nil
+			}
 			_, _ = fmt.Fprint(cmd.OutOrStdout(), string(assetRunner.Config()))
+			return nil
 		},
 	}
 )
 
 func init() {
+	configCmd.Flags().StringVar(&writeFlagVal, "write", "", "Write the configuration to the specified file rather than printing it (for example, to provide the configuration to an editor or to golangci-lint run directly in CI)")
+	configCmd.Flags().BoolVarP(&provenanceFlagVal, "provenance", "", false, "Print the config asset that provided each value of the configuration produced by merging the config assets")
+
 	lintersCmd.AddCommand(configCmd)
 }
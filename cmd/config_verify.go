@@ -0,0 +1,59 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// configVerification is the result of verifying the golangci-lint configuration using "golangci-lint config verify".
+type configVerification int
+
+const (
+	// configValid indicates that the configuration validates against the golangci-lint JSON schema.
+	configValid configVerification = iota
+
+	// configInvalid indicates that the configuration contains elements that are not valid under the golangci-lint
+	// JSON schema.
+	configInvalid
+
+	// configUnverified indicates that the configuration could not be verified (for example, because the JSON schema,
+	// which "golangci-lint config verify" downloads, could not be retrieved).
+	configUnverified
+)
+
+// configInvalidMessage is the error reported by "golangci-lint config verify" when the configuration does not validate
+// against the JSON schema. It is only reported once the schema was retrieved, so it distinguishes an invalid
+// configuration from a failure to verify the configuration.
+const configInvalidMessage = "the configuration contains invalid elements"
+
+// verifyGolangCILintConfig runs "golangci-lint config verify" with the configuration of the provided runner and returns
+// the result of the verification, the exit code of golangci-lint and its combined output.
+func verifyGolangCILintConfig(ctx context.Context, runner GolangCILintRunner, debugMode bool) (configVerification, int, string, error) {
+	var output bytes.Buffer
+	exitCode, err := runner.RunGolangCILintWithConfig(ctx, []string{"config", "verify"}, nil, &output, &output, debugMode)
+	switch {
+	case err != nil:
+		return configUnverified, exitCode, output.String(), err
+	case exitCode == 0:
+		return configValid, exitCode, output.String(), nil
+	case strings.Contains(output.String(), configInvalidMessage):
+		return configInvalid, exitCode, output.String(), nil
+	default:
+		return configUnverified, exitCode, output.String(), nil
+	}
+}
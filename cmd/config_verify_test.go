@@ -0,0 +1,62 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyGolangCILintConfig(t *testing.T) {
+	for i, tc := range []struct {
+		name     string
+		exitCode int
+		output   string
+		want     configVerification
+	}{
+		{
+			name: "valid configuration",
+			want: configValid,
+		},
+		{
+			name:     "invalid configuration",
+			exitCode: 3,
+			output:   "jsonschema: \"linters.enable.0\" does not validate with \"/properties/linters/properties/enable/items/$ref/enum\": value must be one of ...\nError: the configuration contains invalid elements\n",
+			want:     configInvalid,
+		},
+		{
+			name:     "JSON schema could not be downloaded",
+			exitCode: 3,
+			output:   "Error: [.golangci.yml] validate: compile schema: failing loading \"https://golangci-lint.run/jsonschema/golangci.v2.1.jsonschema.json\": dial tcp: lookup golangci-lint.run: no such host\n",
+			want:     configUnverified,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			runner := newFakeGolangCILintRunner(nil)
+			runner.configVerifyExitCode = tc.exitCode
+			runner.configVerifyOutput = tc.output
+
+			verification, exitCode, output, err := verifyGolangCILintConfig(context.Background(), runner, false)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, verification)
+			assert.Equal(t, tc.exitCode, exitCode)
+			assert.Equal(t, tc.output, output)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/doctor"
+	"github.com/palantir/godel-golangci-lint-plugin/config"
+	"github.com/palantir/godel-golangci-lint-plugin/runner"
+	godelconfig "github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the environment of the golangci-lint plugin",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results := []doctor.Result{
+				checkGolangCILintExecutable(),
+				checkConfigAssets(),
+				checkPluginConfig(),
+				checkGodelExcludes(),
+				checkMergedConfig(),
+				doctor.CheckGoEnv(projectDir(), goEnv),
+				doctor.CheckGolangCILintCache(golangCILintCache.Dir),
+				checkStrayConfigFiles(),
+			}
+			if numFailed := doctor.Print(cmd.OutOrStdout(), results); numFailed > 0 {
+				return errors.Errorf("%d check(s) failed", numFailed)
+			}
+			return nil
+		},
+	}
+)
+
+func checkGolangCILintExecutable() doctor.Result {
+	result := doctor.Result{
+		Name: "golangci-lint",
+	}
+	if loadedAssetInfo.GolangCILintAssetPath == "" {
+		result.Status = doctor.StatusFail
+		result.Message = fmt.Sprintf("failed to resolve golangci-lint executable: %v", assetInitErr)
+		result.Fix = "configure the plugin with exactly 1 golangci-lint asset in godel/config/godel.yml, declare golangci-lint as a tool in go.mod, or use the golangci-lint embedded in the plugin (golangci-lint-backend: embedded in the plugin configuration)"
+		return result
+	}
+
+	result.Status = doctor.StatusPass
+	result.Message = fmt.Sprintf("%s at %s (from %s)", loadedAssetInfo.GolangCILintVersion, loadedAssetInfo.GolangCILintAssetPath, loadedAssetInfo.GolangCILintSource)
+	if assetInitErr != nil {
+		// the executable was resolved, but a later step of initialization (such as version verification) failed
+		result.Status = doctor.StatusFail
+		result.Message += "\n" + assetInitErr.Error()
+		result.Fix = "resolve the error above (for example, by using a golangci-lint asset whose version satisfies the declared requirements)"
+	}
+	return result
+}
+
+func checkConfigAssets() doctor.Result {
+	result := doctor.Result{
+		Name:   "config assets",
+		Status: doctor.StatusPass,
+	}
+	if len(loadedAssetInfo.ConfigAssets) == 0 {
+		result.Message = "none (golangci-lint defaults are used as the base configuration)"
+		return result
+	}
+	lines := []string{fmt.Sprintf("%d config asset(s), merged in order:", len(loadedAssetInfo.ConfigAssets))}
+	for _, configAsset := range loadedAssetInfo.ConfigAssets {
+		lines = append(lines, fmt.Sprintf("%s (sha256 %s)", configAsset.Path, configAsset.SHA256))
+	}
+	result.Message = strings.Join(lines, "\n")
+	return result
+}
+
+func checkPluginConfig() doctor.Result {
+	result := doctor.Result{
+		Name: "plugin config",
+	}
+	pluginConfigPath := pluginConfigFile()
+	configBytes, err := os.ReadFile(pluginConfigPath)
+	if err != nil {
+		if os.IsNotExist(err) || pluginConfigPath == "" {
+			result.Status = doctor.StatusPass
+			result.Message = fmt.Sprintf("%q does not exist (no plugin configuration is applied)", pluginConfigPath)
+			return result
+		}
+		result.Status = doctor.StatusFail
+		result.Message = err.Error()
+		result.Fix = fmt.Sprintf("ensure that %s is readable", pluginConfigPath)
+		return result
+	}
+	if _, err := config.PluginConfigFromBytes(configBytes); err != nil {
+		result.Status = doctor.StatusFail
+		result.Message = fmt.Sprintf("%s: %v", pluginConfigPath, err)
+		result.Fix = fmt.Sprintf("correct the YAML syntax of %s", pluginConfigPath)
+		return result
+	}
+	if _, err := config.PluginConfigFromBytesStrict(configBytes); err != nil {
+		result.Status = doctor.StatusWarn
+		result.Message = fmt.Sprintf("%s parses, but strict parsing reported: %v", pluginConfigPath, err)
+		result.Fix = "remove or correct the keys that are not part of the plugin configuration (they are currently ignored)"
+		return result
+	}
+	result.Status = doctor.StatusPass
+	result.Message = fmt.Sprintf("%s parses successfully", pluginConfigPath)
+	return result
+}
+
+func checkGodelExcludes() doctor.Result {
+	result := doctor.Result{
+		Name: "godel excludes",
+	}
+	excludes, err := godelconfig.ReadGodelConfigExcludesFromFile(godelConfigFile())
+	if err != nil {
+		result.Status = doctor.StatusFail
+		result.Message = fmt.Sprintf("failed to read excludes from %q: %v", godelConfigFile(), err)
+		result.Fix = fmt.Sprintf("correct the \"exclude\" section of %s", godelConfigFile())
+		return result
+	}
+	result.Status = doctor.StatusPass
+	exclusionsPaths := config.ExclusionsPathsForExcludeMatchers(excludes)
+	if len(exclusionsPaths) == 0 {
+		result.Message = "none"
+		return result
+	}
+	lines := []string{fmt.Sprintf("names %v and paths %v are converted to linters.exclusions.paths:", excludes.Names, excludes.Paths)}
+	lines = append(lines, exclusionsPaths...)
+	result.Message = strings.Join(lines, "\n")
+	return result
+}
+
+func checkMergedConfig() doctor.Result {
+	result := doctor.Result{
+		Name: "merged config",
+	}
+	if assetRunner == nil {
+		result.Status = doctor.StatusFail
+		result.Message = "not validated because the plugin failed to initialize"
+		result.Fix = "resolve the failures reported by the other checks"
+		return result
+	}
+
+	verification, _, output, err := verifyGolangCILintConfig(context.Background(), assetRunner, false)
+	switch {
+	case err != nil:
+		result.Status = doctor.StatusFail
+		result.Message = err.Error()
+		result.Fix = "ensure that the temporary directory is writable"
+	case verification == configValid:
+		result.Status = doctor.StatusPass
+		result.Message = "passes \"golangci-lint config verify\""
+	case verification == configInvalid:
+		result.Status = doctor.StatusFail
+		result.Message = "\"golangci-lint config verify\" reported invalid elements:\n" + strings.TrimSpace(output)
+		result.Fix = "correct the reported elements in the plugin configuration or config assets (run \"./godelw linters config\" to print the merged configuration)"
+	default:
+		// "config verify" downloads the JSON schema for the configuration, so failures may be due to network access
+		result.Status = doctor.StatusWarn
+		result.Message = "could not run \"golangci-lint config verify\":\n" + strings.TrimSpace(output)
+		result.Fix = "\"golangci-lint config verify\" downloads the configuration JSON schema: ensure that network access is available and re-run"
+	}
+	return result
+}
+
+func checkStrayConfigFiles() doctor.Result {
+	excludes, err := godelconfig.ReadGodelConfigExcludesFromFile(godelConfigFile())
+	if err != nil {
+		return doctor.CheckStrayConfigFiles(projectDir(), nil, runner.ConfigDir)
+	}
+	return doctor.CheckStrayConfigFiles(projectDir(), excludes.Matcher(), runner.ConfigDir)
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	goerrors "errors"
+	"fmt"
+	"io"
+
+	"github.com/palantir/godel-golangci-lint-plugin/report"
+	"github.com/palantir/pkg/cobracli"
+)
+
+// exitCodeError is returned by a command to exit the process with the provided exit code without printing an error.
+// It is used to exit with the exit code of golangci-lint once golangci-lint has finished: returning the error rather
+// than calling os.Exit ensures that deferred cleanup (such as removing temporary files) runs before the process exits.
+type exitCodeError int
+
+func (e exitCodeError) Error() string {
+	// the output of golangci-lint describes the failure, so no error is printed
+	return ""
+}
+
+// exitWithCode returns an error that exits the process with the provided exit code, or nil if the exit code is 0.
+func exitWithCode(exitCode int) error {
+	if exitCode == 0 {
+		return nil
+	}
+	return exitCodeError(exitCode)
+}
+
+// exitWithOutcome prints a one-line summary of the provided outcome of a golangci-lint run that exited with the
+// provided exit code, and returns an error that exits the process with the exit code if the outcome fails the run
+// under the provided policy. Returns nil if golangci-lint succeeded or if the outcome does not fail the run.
+func exitWithOutcome(outcome report.Outcome, exitCode int, stderr io.Writer, policy report.FailPolicy) error {
+	if outcome == report.OutcomeSuccess {
+		return nil
+	}
+	summary := outcome.Summary(exitCode)
+	if !policy.Fails(outcome) {
+		_, _ = fmt.Fprintf(stderr, "%s: not treated as a failure by the plugin configuration\n", summary)
+		return nil
+	}
+	_, _ = fmt.Fprintln(stderr, summary)
+	return exitWithCode(exitCode)
+}
+
+// exitCodeExtractorParam configures the exit code of the process to be the exit code of an exitCodeError returned by a
+// command, or 1 for any other error.
+var exitCodeExtractorParam = cobracli.ExitCodeExtractorParam(func(err error) int {
+	var exitErr exitCodeError
+	if goerrors.As(err, &exitErr) {
+		return int(exitErr)
+	}
+	return 1
+})
@@ -15,21 +15,33 @@
 package cmd
 
 import (
+	"context"
 	"io"
+	"path/filepath"
 
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/assetloader"
 	"github.com/palantir/godel-golangci-lint-plugin/config"
 	"github.com/palantir/godel-golangci-lint-plugin/runner"
 )
 
+// GolangCILintAssetRunner runs the golangci-lint executable at a path that was resolved from the provided source (see
+// getAssetInfo). If the source is assetloader.GolangCILintSourceEmbedded, the executable is the plugin executable,
+// which is run with embedded.EntrypointArg as its first argument so that it runs the golangci-lint linked into it.
 type GolangCILintAssetRunner struct {
-	golangCILintAssetPath string
-	assetConfig           config.GolangCILintConfig
+	golangCILintPath string
+	source           assetloader.GolangCILintSource
+	projectDir       string
+	moduleDir        string
+	env              []string
+	assetConfig      config.GolangCILintConfig
 }
 
-func NewGolangCILintAssetRunner(golangCILintAssetPath string, assetConfig config.GolangCILintConfig) *GolangCILintAssetRunner {
+func NewGolangCILintAssetRunner(golangCILintPath string, source assetloader.GolangCILintSource, projectDir string, assetConfig config.GolangCILintConfig) *GolangCILintAssetRunner {
 	return &GolangCILintAssetRunner{
-		golangCILintAssetPath: golangCILintAssetPath,
-		assetConfig:           assetConfig,
+		golangCILintPath: golangCILintPath,
+		source:           source,
+		projectDir:       projectDir,
+		assetConfig:      assetConfig,
 	}
 }
 
@@ -37,10 +49,42 @@ func (r *GolangCILintAssetRunner) Config() config.GolangCILintConfig {
 	return r.assetConfig
 }
 
-func (r *GolangCILintAssetRunner) RunGolangCILint(args []string, stdout, stderr io.Writer, debugMode bool) int {
-	return runner.RunGolangCILint(r.golangCILintAssetPath, args, stdout, stderr, debugMode)
+func (r *GolangCILintAssetRunner) RunGolangCILint(ctx context.Context, args []string, stdout, stderr io.Writer, debugMode bool) int {
+	return runner.RunGolangCILint(ctx, r.golangCILintPath, r.workDir(), r.env, r.args(args), stdout, stderr, debugMode)
 }
 
-func (r *GolangCILintAssetRunner) RunGolangCILintWithConfig(preConfigArgs, postConfigArgs []string, stdout, stderr io.Writer, debugMode bool) (int, error) {
-	return runner.RunGolangCILintWithConfig(r.golangCILintAssetPath, preConfigArgs, postConfigArgs, r.assetConfig, stdout, stderr, debugMode)
+func (r *GolangCILintAssetRunner) RunGolangCILintWithConfig(ctx context.Context, preConfigArgs, postConfigArgs []string, stdout, stderr io.Writer, debugMode bool) (int, error) {
+	return runner.RunGolangCILintWithConfig(ctx, r.golangCILintPath, r.workDir(), r.projectDir, r.env, r.args(preConfigArgs), postConfigArgs, r.assetConfig, stdout, stderr, debugMode)
+}
+
+func (r *GolangCILintAssetRunner) WithProjectDir(projectDir string) GolangCILintRunner {
+	runner := *r
+	runner.projectDir = projectDir
+	return &runner
+}
+
+func (r *GolangCILintAssetRunner) WithModule(moduleDir string, moduleConfig config.GolangCILintConfig) GolangCILintRunner {
+	runner := *r
+	runner.moduleDir = moduleDir
+	runner.assetConfig = moduleConfig
+	return &runner
+}
+
+func (r *GolangCILintAssetRunner) WithEnv(env []string) GolangCILintRunner {
+	runner := *r
+	runner.env = append(append([]string(nil), r.env...), env...)
+	return &runner
+}
+
+// workDir returns the directory in which golangci-lint is run.
+func (r *GolangCILintAssetRunner) workDir() string {
+	return filepath.Join(r.projectDir, r.moduleDir)
+}
+
+// args returns the arguments with which the executable is run to run golangci-lint with the provided arguments.
+func (r *GolangCILintAssetRunner) args(args []string) []string {
+	if r.source == assetloader.GolangCILintSourceEmbedded {
+		return entrypointArgs(args)
+	}
+	return args
 }
@@ -0,0 +1,35 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/embedded"
+)
+
+// RunEmbeddedGolangCILint runs the golangci-lint embedded in the plugin if the provided process arguments invoke it
+// (see embedded.IsEntrypoint) and returns its exit code and true. Returns false if the arguments do not invoke the
+// embedded golangci-lint.
+func RunEmbeddedGolangCILint(args []string) (int, bool) {
+	if !embedded.IsEntrypoint(args) {
+		return 0, false
+	}
+	return embedded.Main(args[2:]), true
+}
+
+// entrypointArgs returns the arguments of the plugin executable that run the embedded golangci-lint with the provided
+// arguments.
+func entrypointArgs(args []string) []string {
+	return append([]string{embedded.EntrypointArg}, args...)
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/palantir/godel-golangci-lint-plugin/config"
+)
+
+// GolangCILintRunner runs golangci-lint with the configuration used by the plugin.
+type GolangCILintRunner interface {
+	// Config returns the golangci-lint configuration used by the runner.
+	Config() config.GolangCILintConfig
+
+	// RunGolangCILint runs golangci-lint with the provided arguments and returns its exit code. golangci-lint is
+	// stopped if the provided context is done (see runner.GolangCILintCmdRunner).
+	RunGolangCILint(ctx context.Context, args []string, stdout, stderr io.Writer, debugMode bool) int
+
+	// RunGolangCILintWithConfig runs golangci-lint with the provided arguments before and after the flag that specifies
+	// the configuration returned by Config and returns its exit code. golangci-lint is stopped if the provided context
+	// is done.
+	RunGolangCILintWithConfig(ctx context.Context, preConfigArgs, postConfigArgs []string, stdout, stderr io.Writer, debugMode bool) (int, error)
+
+	// WithProjectDir returns a copy of the runner that runs golangci-lint in the provided directory rather than in the
+	// project directory.
+	WithProjectDir(projectDir string) GolangCILintRunner
+
+	// WithModule returns a copy of the runner that runs golangci-lint in the provided module directory (relative to the
+	// project directory) using the provided configuration. The configuration file is still written to the project
+	// directory.
+	WithModule(moduleDir string, moduleConfig config.GolangCILintConfig) GolangCILintRunner
+
+	// WithEnv returns a copy of the runner that adds the provided environment variables (in the form "key=value") to
+	// the environment of golangci-lint.
+	WithEnv(env []string) GolangCILintRunner
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/githook"
+	"github.com/spf13/cobra"
+)
+
+var (
+	installHookForceFlagVal bool
+
+	installHookCmd = &cobra.Command{
+		Use:   "install-hook",
+		Short: "Install a git pre-commit hook that lints the staged changes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hookPath, err := githook.Install(projectDir(), installHookForceFlagVal)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Installed pre-commit hook %s\n", hookPath)
+			return nil
+		},
+	}
+)
+
+func init() {
+	installHookCmd.Flags().BoolVar(&installHookForceFlagVal, "force", false, "Overwrite an existing pre-commit hook")
+
+	rootCmd.AddCommand(installHookCmd)
+}
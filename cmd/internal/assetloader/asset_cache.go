@@ -0,0 +1,238 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assetloader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	// assetCacheFormatVersion is the version of the format of the entries written to the asset cache. It should be
+	// incremented whenever the format changes so that entries written by other versions of the plugin are ignored.
+	assetCacheFormatVersion = 2
+
+	// assetCacheDirEnvVar is the environment variable that can be used to override the directory used for the asset
+	// cache. If the variable is set to the empty string, the cache is disabled.
+	assetCacheDirEnvVar = "GODEL_GOLANGCI_LINT_PLUGIN_ASSET_CACHE_DIR"
+)
+
+// assetFingerprint identifies the content of an asset at a specific path. Two fingerprints are equal only if the path,
+// size, modification time and content hash of the asset are all the same.
+type assetFingerprint struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"modTime"`
+	SHA256  string `json:"sha256"`
+}
+
+// assetCacheEntry is the cached result of successfully verifying that an asset is a golangci-lint executable.
+type assetCacheEntry struct {
+	FormatVersion int              `json:"formatVersion"`
+	Fingerprint   assetFingerprint `json:"fingerprint"`
+	VersionOutput string           `json:"versionOutput"`
+	Version       VersionInfo      `json:"version"`
+}
+
+// assetCache stores the results of verifying golangci-lint assets on disk so that the asset does not need to be
+// executed on every invocation of the plugin. Each entry is stored in its own file whose name is derived from the
+// fingerprint of the asset, so concurrent invocations of the plugin never write to the same file with different
+// content. The zero value is a disabled cache.
+type assetCache struct {
+	dir string
+}
+
+// defaultAssetCache returns the cache stored in the directory specified by the assetCacheDirEnvVar environment variable
+// if it is set, and the "godel-golangci-lint-plugin/assets" directory in the user cache directory otherwise. Returns
+// a disabled cache if the user cache directory cannot be determined.
+func defaultAssetCache() assetCache {
+	if dir, ok := os.LookupEnv(assetCacheDirEnvVar); ok {
+		return assetCache{dir: dir}
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return assetCache{}
+	}
+	return assetCache{dir: filepath.Join(userCacheDir, "godel-golangci-lint-plugin", "assets")}
+}
+
+// load returns the cache entry for the provided fingerprint. Returns false if the cache is disabled or if there is no
+// valid entry for the fingerprint.
+func (c assetCache) load(fingerprint assetFingerprint) (assetCacheEntry, bool) {
+	if c.dir == "" {
+		return assetCacheEntry{}, false
+	}
+	entryBytes, err := os.ReadFile(c.entryPath(fingerprint))
+	if err != nil {
+		return assetCacheEntry{}, false
+	}
+	var entry assetCacheEntry
+	if err := json.Unmarshal(entryBytes, &entry); err != nil {
+		return assetCacheEntry{}, false
+	}
+	if entry.FormatVersion != assetCacheFormatVersion || entry.Fingerprint != fingerprint {
+		return assetCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store writes the provided entry to the cache (see writeEntry). Storing an entry in a disabled cache is a no-op.
+func (c assetCache) store(entry assetCacheEntry) error {
+	if c.dir == "" {
+		return nil
+	}
+	return c.writeEntry(c.entryPath(entry.Fingerprint), entry)
+}
+
+// loadChecksum returns the SHA-256 checksum cached by storeChecksum for the file with the path, size and modification
+// time of the provided fingerprint (whose checksum is ignored). Returns false if the cache is disabled or if there is
+// no valid entry for the file.
+func (c assetCache) loadChecksum(fingerprint assetFingerprint) (string, bool) {
+	if c.dir == "" {
+		return "", false
+	}
+	fingerprint.SHA256 = ""
+	entryBytes, err := os.ReadFile(c.entryPath(fingerprint))
+	if err != nil {
+		return "", false
+	}
+	var entry assetCacheEntry
+	if err := json.Unmarshal(entryBytes, &entry); err != nil {
+		return "", false
+	}
+	cachedFingerprint := entry.Fingerprint
+	cachedFingerprint.SHA256 = ""
+	if entry.FormatVersion != assetCacheFormatVersion || cachedFingerprint != fingerprint || entry.Fingerprint.SHA256 == "" {
+		return "", false
+	}
+	return entry.Fingerprint.SHA256, true
+}
+
+// storeChecksum writes the checksum of the provided fingerprint to the cache so that it is returned by loadChecksum
+// for a file with the same path, size and modification time. Storing a checksum in a disabled cache is a no-op.
+func (c assetCache) storeChecksum(fingerprint assetFingerprint) error {
+	if c.dir == "" {
+		return nil
+	}
+	statFingerprint := fingerprint
+	statFingerprint.SHA256 = ""
+	return c.writeEntry(c.entryPath(statFingerprint), assetCacheEntry{
+		Fingerprint: fingerprint,
+	})
+}
+
+// writeEntry writes the provided entry to the provided path. The entry is written to a temporary file that is then
+// renamed so that readers never observe a partially written entry.
+func (c assetCache) writeEntry(entryPath string, entry assetCacheEntry) error {
+	entry.FormatVersion = assetCacheFormatVersion
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "failed to marshal asset cache entry")
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return pkgerrors.Wrapf(err, "failed to create asset cache directory %s", c.dir)
+	}
+	tmpFile, err := os.CreateTemp(c.dir, ".entry-*.json")
+	if err != nil {
+		return pkgerrors.Wrapf(err, "failed to create temporary asset cache entry")
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+	if _, err := tmpFile.Write(entryBytes); err != nil {
+		_ = tmpFile.Close()
+		return pkgerrors.Wrapf(err, "failed to write asset cache entry")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return pkgerrors.Wrapf(err, "failed to close asset cache entry")
+	}
+	if err := os.Rename(tmpFile.Name(), entryPath); err != nil {
+		return pkgerrors.Wrapf(err, "failed to write asset cache entry")
+	}
+	return nil
+}
+
+func (c assetCache) entryPath(fingerprint assetFingerprint) string {
+	key := sha256.Sum256(fmt.Appendf(nil, "%s\x00%d\x00%d\x00%s", fingerprint.Path, fingerprint.Size, fingerprint.ModTime, fingerprint.SHA256))
+	return filepath.Join(c.dir, hex.EncodeToString(key[:])+".json")
+}
+
+// computeAssetFingerprintWithCache returns the fingerprint of the asset at the provided path in the same manner as
+// computeAssetFingerprint, but the checksum is read from the provided cache if the asset has the same path, size and
+// modification time as when its checksum was cached. This avoids hashing large executables (such as the golangci-lint
+// asset and the plugin executable) on every invocation of the plugin.
+func computeAssetFingerprintWithCache(assetPath string, cache assetCache) (assetFingerprint, error) {
+	absPath, err := filepath.Abs(assetPath)
+	if err != nil {
+		return assetFingerprint{}, pkgerrors.Wrapf(err, "failed to determine absolute path of asset %s", assetPath)
+	}
+	fi, err := os.Stat(absPath)
+	if err != nil {
+		return assetFingerprint{}, pkgerrors.Wrapf(err, "failed to stat asset %s", assetPath)
+	}
+	fingerprint := assetFingerprint{
+		Path:    absPath,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+	}
+	if checksum, ok := cache.loadChecksum(fingerprint); ok {
+		fingerprint.SHA256 = checksum
+		return fingerprint, nil
+	}
+	fingerprint, err = computeAssetFingerprint(assetPath)
+	if err != nil {
+		return assetFingerprint{}, err
+	}
+	// failing to write to the cache is not an error: the checksum is computed again on the next invocation
+	_ = cache.storeChecksum(fingerprint)
+	return fingerprint, nil
+}
+
+// computeAssetFingerprint returns the fingerprint of the asset at the provided path. The path is converted to an
+// absolute path so that the same asset referenced using different relative paths has the same fingerprint.
+func computeAssetFingerprint(assetPath string) (assetFingerprint, error) {
+	absPath, err := filepath.Abs(assetPath)
+	if err != nil {
+		return assetFingerprint{}, pkgerrors.Wrapf(err, "failed to determine absolute path of asset %s", assetPath)
+	}
+	f, err := os.Open(absPath)
+	if err != nil {
+		return assetFingerprint{}, pkgerrors.Wrapf(err, "failed to open asset %s", assetPath)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	fi, err := f.Stat()
+	if err != nil {
+		return assetFingerprint{}, pkgerrors.Wrapf(err, "failed to stat asset %s", assetPath)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return assetFingerprint{}, pkgerrors.Wrapf(err, "failed to compute SHA-256 checksum of asset %s", assetPath)
+	}
+	return assetFingerprint{
+		Path:    absPath,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+		SHA256:  hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
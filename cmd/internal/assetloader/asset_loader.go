@@ -21,47 +21,220 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 
 	pkgerrors "github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
 
+// GolangCILintSource describes where the golangci-lint executable used by the plugin was resolved from.
+type GolangCILintSource string
+
+const (
+	// GolangCILintSourceAsset indicates that the golangci-lint executable is an asset provided to the plugin.
+	GolangCILintSourceAsset GolangCILintSource = "asset"
+
+	// GolangCILintSourceGoTool indicates that the golangci-lint executable was built from a "tool" directive in the
+	// project's go.mod file.
+	GolangCILintSourceGoTool GolangCILintSource = "go.mod tool directive"
+
+	// GolangCILintSourceOverrideFlag indicates that the golangci-lint executable was specified using the
+	// "--golangci-lint-path" flag.
+	GolangCILintSourceOverrideFlag GolangCILintSource = "--golangci-lint-path flag"
+
+	// GolangCILintSourceOverrideEnvVar indicates that the golangci-lint executable was specified using the
+	// GODEL_GOLANGCI_LINT_PATH environment variable.
+	GolangCILintSourceOverrideEnvVar GolangCILintSource = "GODEL_GOLANGCI_LINT_PATH environment variable"
+
+	// GolangCILintSourceEmbedded indicates that the golangci-lint executable is the plugin executable, which runs the
+	// golangci-lint that is linked into the plugin.
+	GolangCILintSourceEmbedded GolangCILintSource = "golangci-lint embedded in the plugin"
+)
+
+// IsOverride returns true if the source is a local override of the golangci-lint executable that would otherwise be
+// used.
+func (s GolangCILintSource) IsOverride() bool {
+	return s == GolangCILintSourceOverrideFlag || s == GolangCILintSourceOverrideEnvVar
+}
+
 type AssetInfo struct {
 	// path to the golangci-lint asset. Must be non-empty.
 	GolangCILintAssetPath string
 
-	// the configuration provided by the configuration asset. May be nil if no configuration asset was provided.
-	ConfigProvidedByAsset []byte
+	// where the golangci-lint executable at GolangCILintAssetPath was resolved from.
+	GolangCILintSource GolangCILintSource
+
+	// the version information reported by the golangci-lint asset.
+	GolangCILintVersion VersionInfo
+
+	// the hex-encoded SHA-256 checksum of the golangci-lint asset.
+	GolangCILintSHA256 string
+
+	// the configuration assets in the order in which they were provided. Each configuration asset is a layer that is
+	// merged on top of the ones before it. May be empty if no configuration asset was provided.
+	ConfigAssets []ConfigAsset
+}
+
+// ConfigAsset is a golangci-lint configuration provided by an asset.
+type ConfigAsset struct {
+	// path to the configuration asset.
+	Path string
+
+	// the YAML content of the configuration asset.
+	Content []byte
+
+	// the hex-encoded SHA-256 checksum of the configuration asset.
+	SHA256 string
 }
 
 // GetAssetInfo verifies that the provided assets are valid and returns an AssetInfo that is properly populated.
 //
 // The provided assets must contain exactly 1 golangci-lint asset.
-// The provided assets may contain at most 1 config asset.
-func GetAssetInfo(assets []string) (AssetInfo, error) {
+// The provided assets may contain any number of config assets, which are returned in the order they were provided.
+//
+// The result of verifying a golangci-lint asset is cached on disk (keyed by the path, size, modification time and
+// content hash of the asset) so that the asset is only executed the first time it is encountered. Assets that are not
+// in the cache are verified concurrently.
+//
+// The assets are verified against the provided checksums (see Checksums) and an error is returned if they do not
+// match.
+func GetAssetInfo(assets []string, checksums Checksums) (AssetInfo, error) {
+	return getAssetInfo(assets, "", GolangCILintSourceAsset, checksums, defaultAssetCache())
+}
+
+// GetAssetInfoWithGolangCILint verifies that the provided assets are valid and returns an AssetInfo that uses the
+// provided golangci-lint executable rather than one selected from the assets. The provided executable is verified in
+// the same manner as a golangci-lint asset. The provided assets are not required to contain a golangci-lint asset (and
+// any golangci-lint assets are ignored), but may contain any number of config assets. The config assets are verified
+// against the provided checksums, but the golangci-lint checksums are not used because the provided executable is not
+// an asset.
+func GetAssetInfoWithGolangCILint(assets []string, golangCILintPath string, source GolangCILintSource, checksums Checksums) (AssetInfo, error) {
+	return getAssetInfo(assets, golangCILintPath, source, checksums, defaultAssetCache())
+}
+
+// GetAssetInfoWithEmbeddedGolangCILint returns an AssetInfo that uses the golangci-lint embedded in the plugin
+// executable at the provided path, which reports the provided version, rather than a golangci-lint asset. The provided
+// assets are only considered as config assets (as in GetAssetInfoWithGolangCILint), and the config assets are verified
+// against the provided checksums. The checksum of the plugin executable is cached (see
+// computeAssetFingerprintWithCache).
+func GetAssetInfoWithEmbeddedGolangCILint(assets []string, pluginPath string, version VersionInfo, checksums Checksums) (AssetInfo, error) {
+	return getEmbeddedAssetInfo(assets, pluginPath, version, checksums, defaultAssetCache())
+}
+
+func getEmbeddedAssetInfo(assets []string, pluginPath string, version VersionInfo, checksums Checksums, cache assetCache) (AssetInfo, error) {
+	fingerprint, err := computeAssetFingerprintWithCache(pluginPath, cache)
+	if err != nil {
+		return AssetInfo{}, err
+	}
+	assetInfo := AssetInfo{
+		GolangCILintAssetPath: pluginPath,
+		GolangCILintSource:    GolangCILintSourceEmbedded,
+		GolangCILintVersion:   version,
+		GolangCILintSHA256:    fingerprint.SHA256,
+		ConfigAssets:          configAssetsOnly(assets, cache),
+	}
+	if err := verifyChecksums(assetInfo, checksums, currentOSArch()); err != nil {
+		return assetInfo, err
+	}
+	return assetInfo, nil
+}
+
+// IsNoGolangCILintAssetError returns true if the provided error was returned by GetAssetInfo because the assets do not
+// contain a golangci-lint asset.
+func IsNoGolangCILintAssetError(err error) bool {
+	var noAssetErr *noGolangCILintAssetError
+	return errors.As(err, &noAssetErr)
+}
+
+// noGolangCILintAssetError is the error returned when the assets provided to the plugin do not contain a golangci-lint
+// asset.
+type noGolangCILintAssetError struct {
+	error
+}
+
+func (e *noGolangCILintAssetError) Unwrap() error {
+	return e.error
+}
+
+// getAssetInfo returns the AssetInfo for the provided assets. If golangCILintPath is non-empty, it is used as the
+// golangci-lint executable and the provided assets are only considered as config assets.
+//
+// If a checksum is declared for the golangci-lint asset, each asset is hashed before it is run to determine whether it
+// is a golangci-lint executable, and assets that do not match the checksum are never run.
+func getAssetInfo(assets []string, golangCILintPath string, source GolangCILintSource, checksums Checksums, cache assetCache) (AssetInfo, error) {
+	var golangCILintChecksum string
+	if len(checksums.GolangCILint) > 0 && golangCILintPath == "" {
+		var err error
+		if golangCILintChecksum, err = expectedChecksum("golangci-lint asset", checksums.GolangCILint, currentOSArch()); err != nil {
+			return AssetInfo{}, err
+		}
+	}
+	assetInfo, err := selectAssets(assets, golangCILintPath, source, golangCILintChecksum, cache)
+	if err != nil {
+		return assetInfo, err
+	}
+	if err := verifyChecksums(assetInfo, checksums, currentOSArch()); err != nil {
+		return assetInfo, err
+	}
+	return assetInfo, nil
+}
+
+// selectAssets returns the AssetInfo for the provided assets without verifying the checksums of the config assets. If
+// golangCILintChecksum is non-empty, only the asset whose SHA-256 checksum matches it is considered as a golangci-lint
+// asset.
+func selectAssets(assets []string, golangCILintPath string, source GolangCILintSource, golangCILintChecksum string, cache assetCache) (AssetInfo, error) {
+	if golangCILintPath != "" {
+		version, sha256Checksum, err := verifyGolangCILintAssetWithCache(golangCILintPath, "", cache)
+		if err != nil {
+			return AssetInfo{}, pkgerrors.Wrapf(err, "golangci-lint executable %s (from %s) is not valid", golangCILintPath, source)
+		}
+		return AssetInfo{
+			GolangCILintAssetPath: golangCILintPath,
+			GolangCILintSource:    source,
+			GolangCILintVersion:   version,
+			GolangCILintSHA256:    sha256Checksum,
+			ConfigAssets:          configAssetsOnly(assets, cache),
+		}, nil
+	}
+
 	var (
 		// stores valid assets
-		golangCILintAssets, configAssets []string
+		golangCILintAssets []string
+		configAssets       []ConfigAsset
 
-		// value returned by the latest valid config asset considered
-		configFromAsset []byte
+		// version and checksum of the latest valid golangci-lint asset considered
+		golangCILintVersion VersionInfo
+		golangCILintSHA256  string
 
 		// errors encountered while trying to load assets
 		golangCILintAssetErrors, configAssetErrors []error
+
+		// error for an asset that is neither a config asset nor matches the golangci-lint checksum
+		checksumMismatchErr error
 	)
 
-	for _, currAsset := range assets {
-		if err := verifyGolangCILintAsset(currAsset); err == nil {
+	for idx, result := range verifyAssets(assets, true, golangCILintChecksum, cache) {
+		currAsset := assets[idx]
+		if result.golangCILintErr == nil {
 			golangCILintAssets = append(golangCILintAssets, currAsset)
+			golangCILintVersion = result.golangCILintVersion
+			golangCILintSHA256 = result.golangCILintSHA256
 		} else {
-			golangCILintAssetErrors = append(golangCILintAssetErrors, err)
+			golangCILintAssetErrors = append(golangCILintAssetErrors, result.golangCILintErr)
 		}
 
-		if config, err := verifyConfigAsset(currAsset); err == nil {
-			configAssets = append(configAssets, currAsset)
-			configFromAsset = config
+		if result.configErr == nil {
+			configAssets = append(configAssets, ConfigAsset{
+				Path:    currAsset,
+				Content: result.config,
+				SHA256:  sha256Hex(result.config),
+			})
 		} else {
-			configAssetErrors = append(configAssetErrors, err)
+			configAssetErrors = append(configAssetErrors, result.configErr)
+			var mismatchErr *checksumMismatchError
+			if checksumMismatchErr == nil && errors.As(result.golangCILintErr, &mismatchErr) {
+				checksumMismatchErr = result.golangCILintErr
+			}
 		}
 	}
 
@@ -72,23 +245,106 @@ func GetAssetInfo(assets []string) (AssetInfo, error) {
 	case 1:
 		// exactly 1 golangci-lint asset found
 		assetInfo.GolangCILintAssetPath = golangCILintAssets[0]
+		assetInfo.GolangCILintSource = source
+		assetInfo.GolangCILintVersion = golangCILintVersion
+		assetInfo.GolangCILintSHA256 = golangCILintSHA256
 	case 0:
-		// no golangci-lint assets found
-		return assetInfo, wrapOrNewError(fmt.Sprintf("plugin must be configured with a single golangci-lint asset, but none was found in assets %v", assets), golangCILintAssetErrors)
+		// no golangci-lint assets found. If an asset that is not a config asset was rejected because it does not match
+		// the golangci-lint checksum, it is most likely the golangci-lint asset.
+		if checksumMismatchErr != nil {
+			return assetInfo, checksumMismatchErr
+		}
+		return assetInfo, &noGolangCILintAssetError{wrapOrNewError(fmt.Sprintf("plugin must be configured with a single golangci-lint asset, but none was found in assets %v", assets), golangCILintAssetErrors)}
 	default:
 		// multiple golangci-lint assets found
 		return assetInfo, pkgerrors.New(fmt.Sprintf("plugin must must be configured with exactly 1 golangci-lint asset, but got %d: %v", numGolangCILintAssets, golangCILintAssets))
 	}
 
-	// verify that at most 1 config asset is specified
-	if numConfigAssets := len(configAssets); numConfigAssets > 0 {
-		if numConfigAssets > 1 {
-			return assetInfo, pkgerrors.New(fmt.Sprintf("plugin must must be configured with at most 1 config asset, but got %d: %v", numConfigAssets, configAssets))
+	assetInfo.ConfigAssets = configAssets
+	return assetInfo, nil
+}
+
+// configAssetsOnly returns the provided assets that are valid config assets in the order in which they were provided.
+// Assets that are not valid config assets (such as golangci-lint executables) are ignored.
+func configAssetsOnly(assets []string, cache assetCache) []ConfigAsset {
+	var configAssets []ConfigAsset
+	for idx, result := range verifyAssets(assets, false, "", cache) {
+		if result.configErr == nil {
+			configAssets = append(configAssets, ConfigAsset{
+				Path:    assets[idx],
+				Content: result.config,
+				SHA256:  sha256Hex(result.config),
+			})
+		}
+	}
+	return configAssets
+}
+
+type assetVerifyResult struct {
+	golangCILintVersion VersionInfo
+	golangCILintSHA256  string
+	golangCILintErr     error
+	config              []byte
+	configErr           error
+}
+
+// verifyAssets verifies each of the provided assets concurrently and returns the results in the same order as the
+// provided assets. If verifyGolangCILint is false, assets are only verified as config assets. If golangCILintChecksum
+// is non-empty, assets that do not match it are not verified as golangci-lint executables (see
+// verifyGolangCILintAssetWithCache).
+func verifyAssets(assets []string, verifyGolangCILint bool, golangCILintChecksum string, cache assetCache) []assetVerifyResult {
+	results := make([]assetVerifyResult, len(assets))
+	var wg sync.WaitGroup
+	for idx, currAsset := range assets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if verifyGolangCILint {
+				results[idx].golangCILintVersion, results[idx].golangCILintSHA256, results[idx].golangCILintErr = verifyGolangCILintAssetWithCache(currAsset, golangCILintChecksum, cache)
+			}
+			results[idx].config, results[idx].configErr = verifyConfigAsset(currAsset)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// verifyGolangCILintAssetWithCache returns the version information and SHA-256 checksum of the golangci-lint executable
+// at the provided path, using the cached verification result for the asset if one exists. If the asset is not in the
+// cache, it is verified using verifyGolangCILintAsset and the result is stored in the cache if the verification
+// succeeds. Failures to read from or write to the cache are not considered errors.
+//
+// If expectedChecksum is non-empty, the checksum of the asset is determined first and a *checksumMismatchError is
+// returned without running the asset (or using the cached verification result) if it does not match. The checksum is
+// only computed if the asset changed since it was last computed (see computeAssetFingerprintWithCache).
+func verifyGolangCILintAssetWithCache(assetPath, expectedChecksum string, cache assetCache) (VersionInfo, string, error) {
+	fingerprint, err := computeAssetFingerprintWithCache(assetPath, cache)
+	if err != nil {
+		return VersionInfo{}, "", err
+	}
+	if expectedChecksum != "" {
+		if err := verifyChecksumValue("golangci-lint asset", assetPath, fingerprint.SHA256, expectedChecksum, currentOSArch()); err != nil {
+			return VersionInfo{}, "", err
 		}
-		assetInfo.ConfigProvidedByAsset = configFromAsset
+	}
+	if entry, ok := cache.load(fingerprint); ok {
+		return entry.Version, fingerprint.SHA256, nil
 	}
 
-	return assetInfo, nil
+	outputBytes, err := verifyGolangCILintAsset(assetPath)
+	if err != nil {
+		return VersionInfo{}, "", err
+	}
+	version, err := ParseVersionInfo(string(outputBytes))
+	if err != nil {
+		return VersionInfo{}, "", err
+	}
+	_ = cache.store(assetCacheEntry{
+		Fingerprint:   fingerprint,
+		VersionOutput: string(outputBytes),
+		Version:       version,
+	})
+	return version, fingerprint.SHA256, nil
 }
 
 func getAssetOutput(assetPath string, args ...string) ([]byte, error) {
@@ -102,17 +358,17 @@ func getAssetOutput(assetPath string, args ...string) ([]byte, error) {
 
 // verifyGolangCILintAsset returns a nil error if the executable at the provided path is a golangci-lint executable,
 // false otherwise. Makes the determination by running the executable with the "--version" argument and verifying that
-// the output matches expectations.
-func verifyGolangCILintAsset(assetPath string) error {
+// the output matches expectations. If the verification succeeds, the output of the command is returned.
+func verifyGolangCILintAsset(assetPath string) ([]byte, error) {
 	args := []string{"--version"}
 	outputBytes, err := getAssetOutput(assetPath, args...)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !bytes.HasPrefix(outputBytes, []byte("golangci-lint has version ")) {
-		return pkgerrors.New(fmt.Sprintf("expected output of command \"%s\" to start with 'golangci-lint has version ', but got: %q", strings.Join(args, " "), outputBytes))
+	if !bytes.HasPrefix(outputBytes, []byte(golangCILintVersionPrefix)) {
+		return nil, pkgerrors.New(fmt.Sprintf("expected output of command \"%s\" to start with '%s', but got: %q", strings.Join(args, " "), golangCILintVersionPrefix, outputBytes))
 	}
-	return nil
+	return outputBytes, nil
 }
 
 func verifyConfigAsset(assetPath string) ([]byte, error) {
@@ -0,0 +1,380 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assetloader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testVersionOutput = "golangci-lint has version v2.1.6 built with go1.24.2 from eabc2638 on 2025-05-04T15:41:19Z"
+
+// writeFakeGolangCILintAsset writes an executable script that prints the provided version output and records each
+// invocation in the returned invocation log file. The script is written so that it is not valid YAML (like a real
+// golangci-lint executable) so that it is not also considered a config asset.
+func writeFakeGolangCILintAsset(t *testing.T, dir, versionOutput string) (assetPath, invocationLogPath string) {
+	assetPath = filepath.Join(dir, "golangci-lint")
+	invocationLogPath = filepath.Join(dir, "invocations.log")
+	script := fmt.Sprintf("#!/bin/sh\n: [not: yaml\necho invoked >> %q\necho %q\n", invocationLogPath, versionOutput)
+	require.NoError(t, os.WriteFile(assetPath, []byte(script), 0755))
+	return assetPath, invocationLogPath
+}
+
+func numInvocations(t *testing.T, invocationLogPath string) int {
+	content, err := os.ReadFile(invocationLogPath)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return strings.Count(string(content), "invoked")
+}
+
+func TestGetAssetInfo(t *testing.T) {
+	dir := t.TempDir()
+	assetPath, _ := writeFakeGolangCILintAsset(t, dir, testVersionOutput)
+	configAssetPath := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configAssetPath, []byte("linters:\n  default: none\n"), 0644))
+	teamConfigAssetPath := filepath.Join(dir, "team-config.yml")
+	require.NoError(t, os.WriteFile(teamConfigAssetPath, []byte("linters:\n  enable:\n    - gosec\n"), 0644))
+
+	assetInfo, err := getAssetInfo([]string{configAssetPath, assetPath, teamConfigAssetPath}, "", GolangCILintSourceAsset, Checksums{}, assetCache{})
+	require.NoError(t, err)
+	assert.Equal(t, assetPath, assetInfo.GolangCILintAssetPath)
+	assert.Equal(t, GolangCILintSourceAsset, assetInfo.GolangCILintSource)
+	assert.Equal(t, VersionInfo{
+		Version:   "v2.1.6",
+		Commit:    "eabc2638",
+		Date:      "2025-05-04T15:41:19Z",
+		GoVersion: "go1.24.2",
+	}, assetInfo.GolangCILintVersion)
+	assert.Equal(t, []ConfigAsset{
+		{
+			Path:    configAssetPath,
+			Content: []byte("linters:\n  default: none\n"),
+			SHA256:  sha256Hex([]byte("linters:\n  default: none\n")),
+		},
+		{
+			Path:    teamConfigAssetPath,
+			Content: []byte("linters:\n  enable:\n    - gosec\n"),
+			SHA256:  sha256Hex([]byte("linters:\n  enable:\n    - gosec\n")),
+		},
+	}, assetInfo.ConfigAssets)
+}
+
+func TestGetAssetInfoUsesCache(t *testing.T) {
+	dir := t.TempDir()
+	assetPath, invocationLogPath := writeFakeGolangCILintAsset(t, dir, testVersionOutput)
+	cache := assetCache{dir: filepath.Join(dir, "cache")}
+
+	for i := 0; i < 3; i++ {
+		assetInfo, err := getAssetInfo([]string{assetPath}, "", GolangCILintSourceAsset, Checksums{}, cache)
+		require.NoError(t, err)
+		assert.Equal(t, "v2.1.6", assetInfo.GolangCILintVersion.Version)
+	}
+	assert.Equal(t, 1, numInvocations(t, invocationLogPath), "asset should only be executed on a cache miss")
+
+	// changing the content of the asset invalidates the cache entry
+	_, _ = writeFakeGolangCILintAsset(t, dir, strings.ReplaceAll(testVersionOutput, "v2.1.6", "v2.2.0"))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(assetPath, modTime, modTime))
+	assetInfo, err := getAssetInfo([]string{assetPath}, "", GolangCILintSourceAsset, Checksums{}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, "v2.2.0", assetInfo.GolangCILintVersion.Version)
+	assert.Equal(t, 2, numInvocations(t, invocationLogPath))
+}
+
+func TestGetAssetInfoWithGolangCILint(t *testing.T) {
+	dir := t.TempDir()
+	toolPath, _ := writeFakeGolangCILintAsset(t, dir, testVersionOutput)
+	assetPath, assetInvocationLogPath := writeFakeGolangCILintAsset(t, t.TempDir(), testVersionOutput)
+	configAssetPath := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configAssetPath, []byte("linters:\n  default: none\n"), 0644))
+
+	assetInfo, err := getAssetInfo([]string{assetPath, configAssetPath}, toolPath, GolangCILintSourceGoTool, Checksums{}, assetCache{})
+	require.NoError(t, err)
+	assert.Equal(t, toolPath, assetInfo.GolangCILintAssetPath)
+	assert.Equal(t, GolangCILintSourceGoTool, assetInfo.GolangCILintSource)
+	assert.Equal(t, "v2.1.6", assetInfo.GolangCILintVersion.Version)
+	assert.NotEmpty(t, assetInfo.GolangCILintSHA256)
+	assert.Equal(t, []ConfigAsset{
+		{
+			Path:    configAssetPath,
+			Content: []byte("linters:\n  default: none\n"),
+			SHA256:  sha256Hex([]byte("linters:\n  default: none\n")),
+		},
+	}, assetInfo.ConfigAssets)
+	assert.Equal(t, 0, numInvocations(t, assetInvocationLogPath), "golangci-lint asset should not be executed")
+}
+
+func TestGetAssetInfoWithEmbeddedGolangCILint(t *testing.T) {
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "plugin")
+	require.NoError(t, os.WriteFile(pluginPath, []byte("plugin"), 0755))
+	assetPath, assetInvocationLogPath := writeFakeGolangCILintAsset(t, t.TempDir(), testVersionOutput)
+	configAssetPath := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configAssetPath, []byte("linters:\n  default: none\n"), 0644))
+	version := VersionInfo{
+		Version:   "v2.1.6",
+		GoVersion: "go1.24.2",
+	}
+
+	assetInfo, err := getEmbeddedAssetInfo([]string{assetPath, configAssetPath}, pluginPath, version, Checksums{}, assetCache{})
+	require.NoError(t, err)
+	assert.Equal(t, AssetInfo{
+		GolangCILintAssetPath: pluginPath,
+		GolangCILintSource:    GolangCILintSourceEmbedded,
+		GolangCILintVersion:   version,
+		GolangCILintSHA256:    sha256Hex([]byte("plugin")),
+		ConfigAssets: []ConfigAsset{
+			{
+				Path:    configAssetPath,
+				Content: []byte("linters:\n  default: none\n"),
+				SHA256:  sha256Hex([]byte("linters:\n  default: none\n")),
+			},
+		},
+	}, assetInfo)
+	assert.Equal(t, 0, numInvocations(t, assetInvocationLogPath), "golangci-lint asset should not be executed")
+}
+
+func TestGetAssetInfoWithEmbeddedGolangCILintCachesChecksum(t *testing.T) {
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "plugin")
+	require.NoError(t, os.WriteFile(pluginPath, []byte("plugin"), 0755))
+	cache := assetCache{dir: filepath.Join(dir, "cache")}
+
+	assetInfo, err := getEmbeddedAssetInfo(nil, pluginPath, VersionInfo{}, Checksums{}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex([]byte("plugin")), assetInfo.GolangCILintSHA256)
+
+	// the cached checksum is used if the size and modification time of the plugin executable are unchanged
+	fi, err := os.Stat(pluginPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(pluginPath, []byte("PLUGIN"), 0755))
+	require.NoError(t, os.Chtimes(pluginPath, fi.ModTime(), fi.ModTime()))
+	assetInfo, err = getEmbeddedAssetInfo(nil, pluginPath, VersionInfo{}, Checksums{}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex([]byte("plugin")), assetInfo.GolangCILintSHA256)
+
+	// the checksum is computed again if the plugin executable is replaced
+	require.NoError(t, os.WriteFile(pluginPath, []byte("new plugin"), 0755))
+	assetInfo, err = getEmbeddedAssetInfo(nil, pluginPath, VersionInfo{}, Checksums{}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex([]byte("new plugin")), assetInfo.GolangCILintSHA256)
+}
+
+func TestGetAssetInfoErrors(t *testing.T) {
+	dir := t.TempDir()
+	assetPath, _ := writeFakeGolangCILintAsset(t, dir, testVersionOutput)
+	otherAssetPath, _ := writeFakeGolangCILintAsset(t, t.TempDir(), testVersionOutput)
+
+	_, err := getAssetInfo(nil, "", GolangCILintSourceAsset, Checksums{}, assetCache{})
+	assert.EqualError(t, err, "plugin must be configured with a single golangci-lint asset, but none was found in assets []")
+	assert.True(t, IsNoGolangCILintAssetError(err))
+
+	_, err = getAssetInfo([]string{assetPath, otherAssetPath}, "", GolangCILintSourceAsset, Checksums{}, assetCache{})
+	assert.ErrorContains(t, err, "plugin must must be configured with exactly 1 golangci-lint asset, but got 2")
+	assert.False(t, IsNoGolangCILintAssetError(err))
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		versionOutput string
+		want          VersionInfo
+		wantErr       string
+	}{
+		{
+			name:          "release build",
+			versionOutput: testVersionOutput + "\n",
+			want: VersionInfo{
+				Version:   "v2.1.6",
+				Commit:    "eabc2638",
+				Date:      "2025-05-04T15:41:19Z",
+				GoVersion: "go1.24.2",
+			},
+		},
+		{
+			name:          "build using go build",
+			versionOutput: `golangci-lint has version v2.1.6 built with go1.24.3 from (unknown, modified: ?, mod sum: "h1:abc=") on (unknown)`,
+			want: VersionInfo{
+				Version:   "v2.1.6",
+				Commit:    `(unknown, modified: ?, mod sum: "h1:abc=")`,
+				Date:      "(unknown)",
+				GoVersion: "go1.24.3",
+			},
+		},
+		{
+			name:          "legacy format only populates version",
+			versionOutput: "golangci-lint has version 1.23.0 built from abcdef on 2020-01-01",
+			want: VersionInfo{
+				Version: "1.23.0",
+			},
+		},
+		{
+			name:          "unexpected output",
+			versionOutput: "some other tool 1.0.0",
+			wantErr:       `expected version output to start with 'golangci-lint has version ', but got: "some other tool 1.0.0"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseVersionInfo(tc.versionOutput)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestVerifyGolangCILintVersion(t *testing.T) {
+	assetInfo := AssetInfo{
+		GolangCILintAssetPath: "/assets/golangci-lint",
+		GolangCILintVersion: VersionInfo{
+			Version: "v2.1.6",
+		},
+	}
+
+	assert.NoError(t, VerifyGolangCILintVersion(assetInfo))
+	assert.NoError(t, VerifyGolangCILintVersion(assetInfo,
+		VersionConstraint{Constraint: "", Source: "the config asset"},
+		VersionConstraint{Constraint: ">=2.1.0 <3", Source: "the plugin configuration"},
+	))
+
+	err := VerifyGolangCILintVersion(assetInfo,
+		VersionConstraint{Constraint: ">=2.1.0 <3", Source: "the config asset"},
+		VersionConstraint{Constraint: ">=2.2.0", Source: "the plugin configuration"},
+	)
+	assert.EqualError(t, err, `the plugin configuration requires golangci-lint version ">=2.2.0", but golangci-lint executable /assets/golangci-lint has version v2.1.6: configure the plugin with a golangci-lint asset whose version is in the required range`)
+
+	err = VerifyGolangCILintVersion(AssetInfo{
+		GolangCILintAssetPath: "/assets/golangci-lint",
+		GolangCILintVersion: VersionInfo{
+			Version: "(devel)",
+		},
+	}, VersionConstraint{Constraint: ">=2.1.0", Source: "the config asset"})
+	assert.EqualError(t, err, `the config asset requires golangci-lint version ">=2.1.0", but the version "(devel)" reported by golangci-lint executable /assets/golangci-lint is not a valid semantic version`)
+}
+
+func TestGetAssetInfoVerifiesChecksums(t *testing.T) {
+	dir := t.TempDir()
+	assetPath, _ := writeFakeGolangCILintAsset(t, dir, testVersionOutput)
+	configAssetPath := filepath.Join(dir, "config.yml")
+	configContent := []byte("linters:\n  default: none\n")
+	require.NoError(t, os.WriteFile(configAssetPath, configContent, 0644))
+
+	assetContent, err := os.ReadFile(assetPath)
+	require.NoError(t, err)
+	osArch := currentOSArch()
+
+	_, err = getAssetInfo([]string{assetPath, configAssetPath}, "", GolangCILintSourceAsset, Checksums{
+		GolangCILint: map[string]string{
+			osArch: strings.ToUpper(sha256Hex(assetContent)),
+		},
+		Config: []map[string]string{
+			{
+				osArch: sha256Hex(configContent),
+			},
+		},
+	}, assetCache{})
+	assert.NoError(t, err)
+
+	_, err = getAssetInfo([]string{assetPath, configAssetPath}, "", GolangCILintSourceAsset, Checksums{
+		GolangCILint: map[string]string{
+			osArch: sha256Hex([]byte("other content")),
+		},
+	}, assetCache{})
+	assert.EqualError(t, err, fmt.Sprintf("SHA-256 checksum of golangci-lint asset %s does not match the checksum declared for %s: expected %s, was %s. Refusing to run an asset that does not match its declared checksum",
+		assetPath, osArch, sha256Hex([]byte("other content")), sha256Hex(assetContent)))
+
+	_, err = getAssetInfo([]string{assetPath, configAssetPath}, "", GolangCILintSourceAsset, Checksums{
+		Config: []map[string]string{
+			{
+				"plan9-386": sha256Hex(configContent),
+			},
+		},
+	}, assetCache{})
+	assert.EqualError(t, err, fmt.Sprintf("checksums are declared for the config asset, but no checksum is declared for %s", osArch))
+
+	// golangci-lint checksums are not verified for executables that are not assets
+	_, err = getAssetInfo([]string{configAssetPath}, assetPath, GolangCILintSourceOverrideFlag, Checksums{
+		GolangCILint: map[string]string{
+			osArch: sha256Hex([]byte("other content")),
+		},
+	}, assetCache{})
+	assert.NoError(t, err)
+}
+
+func TestGetAssetInfoDoesNotRunAssetWithMismatchedChecksum(t *testing.T) {
+	dir := t.TempDir()
+	assetPath, invocationLogPath := writeFakeGolangCILintAsset(t, dir, testVersionOutput)
+	configAssetPath := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configAssetPath, []byte("linters:\n  default: none\n"), 0644))
+	cache := assetCache{dir: filepath.Join(dir, "cache")}
+	checksums := Checksums{
+		GolangCILint: map[string]string{
+			currentOSArch(): sha256Hex([]byte("other content")),
+		},
+	}
+
+	_, err := getAssetInfo([]string{configAssetPath, assetPath}, "", GolangCILintSourceAsset, checksums, cache)
+	assert.ErrorContains(t, err, "Refusing to run an asset that does not match its declared checksum")
+	_, err = os.Stat(invocationLogPath)
+	assert.True(t, os.IsNotExist(err), "asset that does not match its checksum must not be run")
+
+	// an asset that does not match the checksum is not run even if a verification result for it is cached
+	_, err = getAssetInfo([]string{configAssetPath, assetPath}, "", GolangCILintSourceAsset, Checksums{}, cache)
+	require.NoError(t, err)
+	require.Equal(t, 1, numInvocations(t, invocationLogPath))
+	_, err = getAssetInfo([]string{configAssetPath, assetPath}, "", GolangCILintSourceAsset, checksums, cache)
+	assert.ErrorContains(t, err, "Refusing to run an asset that does not match its declared checksum")
+	assert.Equal(t, 1, numInvocations(t, invocationLogPath))
+}
+
+func TestGetAssetInfoCachesVerifiedChecksum(t *testing.T) {
+	dir := t.TempDir()
+	assetPath, _ := writeFakeGolangCILintAsset(t, dir, testVersionOutput)
+	assetContent, err := os.ReadFile(assetPath)
+	require.NoError(t, err)
+	cache := assetCache{dir: filepath.Join(dir, "cache")}
+	checksums := Checksums{
+		GolangCILint: map[string]string{
+			currentOSArch(): sha256Hex(assetContent),
+		},
+	}
+
+	assetInfo, err := getAssetInfo([]string{assetPath}, "", GolangCILintSourceAsset, checksums, cache)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex(assetContent), assetInfo.GolangCILintSHA256)
+
+	// the asset is not hashed again if its size and modification time are unchanged, so the cached checksum is used
+	fi, err := os.Stat(assetPath)
+	require.NoError(t, err)
+	checksum, ok := cache.loadChecksum(assetFingerprint{
+		Path:    assetPath,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+	})
+	require.True(t, ok)
+	assert.Equal(t, sha256Hex(assetContent), checksum)
+}
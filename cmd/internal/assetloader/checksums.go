@@ -0,0 +1,105 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assetloader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/palantir/godel/v2/pkg/osarch"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Checksums are the expected SHA-256 checksums of the assets provided to the plugin. Each checksum map is keyed by
+// OS/architecture (for example, "linux-amd64") in the same manner as the "checksums" of a godel plugin locator, and
+// only the checksum for the current OS/architecture is verified.
+type Checksums struct {
+	// expected checksums of the golangci-lint asset. If empty, the golangci-lint asset is not verified.
+	GolangCILint map[string]string
+
+	// expected checksums of the config assets, in the same order as the config assets. If empty, config assets are
+	// not verified; otherwise, there must be exactly one element for each config asset.
+	Config []map[string]string
+}
+
+func currentOSArch() string {
+	return osarch.Current().String()
+}
+
+// verifyChecksums returns an error if the assets in the provided AssetInfo do not match the provided checksums for the
+// provided OS/architecture. The golangci-lint checksums are only verified if the golangci-lint executable is an
+// asset.
+func verifyChecksums(assetInfo AssetInfo, checksums Checksums, osArch string) error {
+	if len(checksums.GolangCILint) > 0 && assetInfo.GolangCILintSource == GolangCILintSourceAsset {
+		if err := verifyChecksum("golangci-lint asset", assetInfo.GolangCILintAssetPath, assetInfo.GolangCILintSHA256, checksums.GolangCILint, osArch); err != nil {
+			return err
+		}
+	}
+
+	if len(checksums.Config) == 0 {
+		return nil
+	}
+	if len(checksums.Config) != len(assetInfo.ConfigAssets) {
+		return pkgerrors.Errorf("checksums are declared for %d config assets, but %d config assets were provided", len(checksums.Config), len(assetInfo.ConfigAssets))
+	}
+	for idx, configAsset := range assetInfo.ConfigAssets {
+		if err := verifyChecksum("config asset", configAsset.Path, configAsset.SHA256, checksums.Config[idx], osArch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyChecksum(assetDescription, assetPath, actualChecksum string, expectedChecksums map[string]string, osArch string) error {
+	checksum, err := expectedChecksum(assetDescription, expectedChecksums, osArch)
+	if err != nil {
+		return err
+	}
+	return verifyChecksumValue(assetDescription, assetPath, actualChecksum, checksum, osArch)
+}
+
+// expectedChecksum returns the checksum in the provided checksums for the provided OS/architecture.
+func expectedChecksum(assetDescription string, expectedChecksums map[string]string, osArch string) (string, error) {
+	checksum, ok := expectedChecksums[osArch]
+	if !ok {
+		return "", pkgerrors.Errorf("checksums are declared for the %s, but no checksum is declared for %s", assetDescription, osArch)
+	}
+	return checksum, nil
+}
+
+// verifyChecksumValue returns a *checksumMismatchError if the provided actual checksum does not match the provided
+// expected checksum.
+func verifyChecksumValue(assetDescription, assetPath, actualChecksum, expectedChecksum, osArch string) error {
+	if !strings.EqualFold(expectedChecksum, actualChecksum) {
+		return &checksumMismatchError{pkgerrors.Errorf("SHA-256 checksum of %s %s does not match the checksum declared for %s: expected %s, was %s. Refusing to run an asset that does not match its declared checksum",
+			assetDescription, assetPath, osArch, expectedChecksum, actualChecksum)}
+	}
+	return nil
+}
+
+// checksumMismatchError is the error returned when the checksum of an asset does not match its declared checksum.
+type checksumMismatchError struct {
+	error
+}
+
+func (e *checksumMismatchError) Unwrap() error {
+	return e.error
+}
+
+func sha256Hex(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return hex.EncodeToString(checksum[:])
+}
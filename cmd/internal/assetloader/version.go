@@ -0,0 +1,115 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assetloader
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const golangCILintVersionPrefix = "golangci-lint has version "
+
+// versionOutputRegexp matches the output of "golangci-lint --version", which has the form
+// "golangci-lint has version <version> built with <go version> from <commit> on <date>". The commit may contain spaces
+// for executables built using "go install" or "go build" (for example, "(unknown, modified: ?, mod sum: "")").
+var versionOutputRegexp = regexp.MustCompile(`^` + regexp.QuoteMeta(golangCILintVersionPrefix) + `(\S+) built with (\S+) from (.+) on (\S+)$`)
+
+// VersionInfo is the version information reported by a golangci-lint executable.
+type VersionInfo struct {
+	// the version of golangci-lint (for example, "v2.1.6" or "2.1.6").
+	Version string `json:"version"`
+
+	// the commit from which golangci-lint was built. May be empty if the output did not contain commit information.
+	Commit string `json:"commit,omitempty"`
+
+	// the date on which golangci-lint was built. May be empty if the output did not contain a build date.
+	Date string `json:"date,omitempty"`
+
+	// the version of Go used to build golangci-lint (for example, "go1.24.2"). May be empty if the output did not
+	// contain the Go version.
+	GoVersion string `json:"goVersion,omitempty"`
+}
+
+func (v VersionInfo) String() string {
+	if v.GoVersion == "" {
+		return v.Version
+	}
+	return fmt.Sprintf("%s (built with %s)", v.Version, v.GoVersion)
+}
+
+// ParseVersionInfo parses the output of running "golangci-lint --version". Returns an error if the output does not
+// start with "golangci-lint has version ". If the output is not in the format of golangci-lint v1.54+, only the
+// version is populated.
+func ParseVersionInfo(versionOutput string) (VersionInfo, error) {
+	versionOutput = strings.TrimSpace(versionOutput)
+	if !strings.HasPrefix(versionOutput, golangCILintVersionPrefix) {
+		return VersionInfo{}, pkgerrors.Errorf("expected version output to start with '%s', but got: %q", golangCILintVersionPrefix, versionOutput)
+	}
+	if match := versionOutputRegexp.FindStringSubmatch(versionOutput); match != nil {
+		return VersionInfo{
+			Version:   match[1],
+			GoVersion: match[2],
+			Commit:    match[3],
+			Date:      match[4],
+		}, nil
+	}
+	fields := strings.Fields(strings.TrimPrefix(versionOutput, golangCILintVersionPrefix))
+	if len(fields) == 0 {
+		return VersionInfo{}, pkgerrors.Errorf("version output did not contain a version: %q", versionOutput)
+	}
+	return VersionInfo{
+		Version: fields[0],
+	}, nil
+}
+
+// VersionConstraint is a semantic version range that the golangci-lint executable must satisfy.
+type VersionConstraint struct {
+	// the version range (for example, ">=2.1.0 <3"). Supports the syntax of github.com/Masterminds/semver/v3.
+	Constraint string
+
+	// describes where the constraint was declared. Used in error messages.
+	Source string
+}
+
+// VerifyGolangCILintVersion returns an error if the version of the golangci-lint executable described by the provided
+// AssetInfo does not satisfy all the provided constraints. Constraints with an empty Constraint are ignored.
+func VerifyGolangCILintVersion(assetInfo AssetInfo, constraints ...VersionConstraint) error {
+	var version *semver.Version
+	for _, constraint := range constraints {
+		if constraint.Constraint == "" {
+			continue
+		}
+		semverConstraint, err := semver.NewConstraint(constraint.Constraint)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "invalid golangci-lint version range %q specified in %s", constraint.Constraint, constraint.Source)
+		}
+		if version == nil {
+			version, err = semver.NewVersion(assetInfo.GolangCILintVersion.Version)
+			if err != nil {
+				return pkgerrors.Errorf("%s requires golangci-lint version %q, but the version %q reported by golangci-lint executable %s is not a valid semantic version",
+					constraint.Source, constraint.Constraint, assetInfo.GolangCILintVersion.Version, assetInfo.GolangCILintAssetPath)
+			}
+		}
+		if !semverConstraint.Check(version) {
+			return pkgerrors.Errorf("%s requires golangci-lint version %q, but golangci-lint executable %s has version %s: configure the plugin with a golangci-lint asset whose version is in the required range",
+				constraint.Source, constraint.Constraint, assetInfo.GolangCILintAssetPath, assetInfo.GolangCILintVersion.Version)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changes determines the packages of a project that are affected by the changes since a git revision, so that
+// linting can be limited to those packages.
+package changes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/git"
+	"github.com/pkg/errors"
+)
+
+// Package is a package of the project as reported by "go list".
+type Package struct {
+	ImportPath   string
+	Dir          string
+	Imports      []string
+	TestImports  []string
+	XTestImports []string
+	Module       *Module
+}
+
+// Module is the module that contains a package.
+type Module struct {
+	Path string
+	Dir  string
+}
+
+// MergeBase returns the best common ancestor of HEAD and the provided branch (or other revision).
+func MergeBase(projectDir, branch string) (string, error) {
+	output, err := git.Output(projectDir, "merge-base", branch, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// ChangedFiles returns the paths (relative to the provided project directory) of the files in the project directory
+// that differ between the provided revision and the working tree, including staged changes and untracked files that
+// are not ignored. Renames are reported as a deletion and an addition so that both paths are returned.
+func ChangedFiles(projectDir, rev string) ([]string, error) {
+	diffOutput, err := git.Output(projectDir, "diff", "--name-only", "--no-renames", "--relative", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	untrackedOutput, err := git.Output(projectDir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFileList(diffOutput + "\n" + untrackedOutput), nil
+}
+
+// parseFileList returns the sorted paths in the provided output of a git command that prints one path per line.
+func parseFileList(output string) []string {
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, filepath.FromSlash(line))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// PackagesChangedSince returns the directories of the packages in the provided project directory that are affected by
+// the changes since the provided revision (see AffectedPackages). The provided environment variables (in the form
+// "key=value") are added to the environment of the go command that lists the packages.
+func PackagesChangedSince(projectDir, rev string, env []string) ([]string, bool, error) {
+	absProjectDir, err := resolvedAbsPath(projectDir)
+	if err != nil {
+		return nil, false, err
+	}
+	changedFiles, err := ChangedFiles(absProjectDir, rev)
+	if err != nil {
+		return nil, false, err
+	}
+	return affectedPackagesInDir(absProjectDir, changedFiles, env)
+}
+
+// affectedPackagesInDir lists the packages in the provided directory and returns the directories of the packages that
+// are affected by changes to the provided files (see AffectedPackages).
+func affectedPackagesInDir(dir string, changedFiles, env []string) ([]string, bool, error) {
+	if len(changedFiles) == 0 {
+		return nil, false, nil
+	}
+	pkgs, err := ListPackages(dir, env)
+	if err != nil {
+		return nil, false, err
+	}
+	dirs, all := AffectedPackages(dir, changedFiles, pkgs)
+	return dirs, all, nil
+}
+
+// resolvedAbsPath returns the absolute path of the provided path with symbolic links resolved, which is the form of
+// the directories reported by "go list".
+func resolvedAbsPath(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to determine absolute path of %s", path)
+	}
+	if absPath, err = filepath.EvalSymlinks(absPath); err != nil {
+		return "", errors.Wrapf(err, "failed to resolve symbolic links in %s", path)
+	}
+	return absPath, nil
+}
+
+// ListPackages returns the packages in the provided project directory ("go list ./..."). The provided environment
+// variables (in the form "key=value") are added to the environment of the go command.
+func ListPackages(projectDir string, env []string) ([]Package, error) {
+	cmd := exec.Command("go", "list", "-e", "-json=ImportPath,Dir,Imports,TestImports,XTestImports,Module", "./...")
+	cmd.Dir = projectDir
+	cmd.Env = append(os.Environ(), env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run %q: %s", strings.Join(cmd.Args, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	var pkgs []Package
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var pkg Package
+		if err := decoder.Decode(&pkg); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrapf(err, "failed to parse output of %q", strings.Join(cmd.Args, " "))
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+// AffectedPackages returns the directories of the provided packages that are affected by changes to the provided files
+// (paths relative to the provided project directory), which are the packages in the directories of changed files and
+// all of the packages that directly or indirectly import them (including from tests). The returned directories are
+// relative to the project directory, sorted, and in the form "./dir" (or "." for the project directory) so that they
+// can be provided as arguments to golangci-lint.
+//
+// Changes to Go files in a directory that does not contain a package (for example, because the package was deleted)
+// affect the packages that import the package that used to be in that directory. Changes to other files outside of
+// package directories do not affect any package.
+//
+// If the changes affect the module configuration or dependencies of the project (go.mod, go.sum, go.work or vendored
+// code), all packages may be affected and "all" is true.
+func AffectedPackages(projectDir string, changedFiles []string, pkgs []Package) (dirs []string, all bool) {
+	pkgsByDir := make(map[string]Package)
+	importers := make(map[string][]Package)
+	for _, pkg := range pkgs {
+		pkgsByDir[pkg.Dir] = pkg
+		for _, imports := range [][]string{pkg.Imports, pkg.TestImports, pkg.XTestImports} {
+			for _, importPath := range imports {
+				importers[importPath] = append(importers[importPath], pkg)
+			}
+		}
+	}
+
+	var changedImportPaths []string
+	for _, file := range changedFiles {
+		switch filepath.Base(file) {
+		case "go.mod", "go.sum", "go.work", "go.work.sum":
+			return nil, true
+		}
+		if strings.SplitN(filepath.ToSlash(file), "/", 2)[0] == "vendor" {
+			return nil, true
+		}
+
+		dir := filepath.Join(projectDir, filepath.Dir(file))
+		if pkg, ok := pkgsByDir[dir]; ok {
+			changedImportPaths = append(changedImportPaths, pkg.ImportPath)
+		} else if filepath.Ext(file) == ".go" {
+			if importPath, ok := importPathForDir(dir, pkgs); ok {
+				changedImportPaths = append(changedImportPaths, importPath)
+			}
+		}
+	}
+
+	affected := make(map[string]string)
+	visited := make(map[string]struct{})
+	for len(changedImportPaths) > 0 {
+		importPath := changedImportPaths[0]
+		changedImportPaths = changedImportPaths[1:]
+		if _, ok := visited[importPath]; ok {
+			continue
+		}
+		visited[importPath] = struct{}{}
+
+		for _, pkg := range pkgs {
+			if pkg.ImportPath == importPath {
+				affected[pkg.Dir] = pkg.ImportPath
+			}
+		}
+		for _, importer := range importers[importPath] {
+			changedImportPaths = append(changedImportPaths, importer.ImportPath)
+		}
+	}
+
+	for dir := range affected {
+		if !isWithin(projectDir, dir) {
+			// only packages in the project directory are linted
+			continue
+		}
+		relDir, _ := filepath.Rel(projectDir, dir)
+		if relDir == "." {
+			dirs = append(dirs, ".")
+			continue
+		}
+		dirs = append(dirs, "."+string(filepath.Separator)+relDir)
+	}
+	sort.Strings(dirs)
+	return dirs, false
+}
+
+// importPathForDir returns the import path of the package in the provided directory based on the innermost module of
+// the provided packages that contains the directory.
+func importPathForDir(dir string, pkgs []Package) (string, bool) {
+	var module *Module
+	for _, pkg := range pkgs {
+		if pkg.Module == nil || pkg.Module.Dir == "" || !isWithin(pkg.Module.Dir, dir) {
+			continue
+		}
+		if module == nil || len(pkg.Module.Dir) > len(module.Dir) {
+			module = pkg.Module
+		}
+	}
+	if module == nil {
+		return "", false
+	}
+	relDir, _ := filepath.Rel(module.Dir, dir)
+	if relDir == "." {
+		return module.Path, true
+	}
+	return module.Path + "/" + filepath.ToSlash(relDir), true
+}
+
+// isWithin returns true if the provided path is the provided directory or is within it.
+func isWithin(dir, path string) bool {
+	relPath, err := filepath.Rel(dir, path)
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changes
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffectedPackages(t *testing.T) {
+	projectDir := filepath.FromSlash("/project")
+	module := &Module{
+		Path: "example.com/project",
+		Dir:  projectDir,
+	}
+	pkgs := []Package{
+		{
+			ImportPath: "example.com/project",
+			Dir:        projectDir,
+			Imports:    []string{"example.com/project/a", "fmt"},
+			Module:     module,
+		},
+		{
+			ImportPath: "example.com/project/a",
+			Dir:        filepath.Join(projectDir, "a"),
+			Imports:    []string{"example.com/project/b"},
+			Module:     module,
+		},
+		{
+			ImportPath:  "example.com/project/b",
+			Dir:         filepath.Join(projectDir, "b"),
+			TestImports: []string{"example.com/project/testutil"},
+			Module:      module,
+		},
+		{
+			ImportPath: "example.com/project/c",
+			Dir:        filepath.Join(projectDir, "c"),
+			Imports:    []string{"example.com/project/deleted"},
+			Module:     module,
+		},
+		{
+			ImportPath: "example.com/project/testutil",
+			Dir:        filepath.Join(projectDir, "testutil"),
+			Module:     module,
+		},
+	}
+
+	for i, tc := range []struct {
+		name         string
+		changedFiles []string
+		wantDirs     []string
+		wantAll      bool
+	}{
+		{
+			name:         "package and its reverse dependencies are affected",
+			changedFiles: []string{"b/b.go"},
+			wantDirs:     []string{".", "./a", "./b"},
+		},
+		{
+			name:         "packages that import a package in tests are affected",
+			changedFiles: []string{"testutil/testutil.go"},
+			wantDirs:     []string{".", "./a", "./b", "./testutil"},
+		},
+		{
+			name:         "importers of deleted package are affected",
+			changedFiles: []string{"deleted/deleted.go"},
+			wantDirs:     []string{"./c"},
+		},
+		{
+			name:         "non-Go files outside of package directories do not affect any package",
+			changedFiles: []string{"docs/README.md"},
+		},
+		{
+			name:         "go.sum change affects all packages",
+			changedFiles: []string{"a/a.go", "go.sum"},
+			wantAll:      true,
+		},
+		{
+			name:         "vendored code change affects all packages",
+			changedFiles: []string{"vendor/github.com/foo/bar/bar.go"},
+			wantAll:      true,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			var changedFiles []string
+			for _, file := range tc.changedFiles {
+				changedFiles = append(changedFiles, filepath.FromSlash(file))
+			}
+			var wantDirs []string
+			for _, dir := range tc.wantDirs {
+				wantDirs = append(wantDirs, filepath.FromSlash(dir))
+			}
+
+			gotDirs, gotAll := AffectedPackages(projectDir, changedFiles, pkgs)
+			assert.Equal(t, wantDirs, gotDirs)
+			assert.Equal(t, tc.wantAll, gotAll)
+		})
+	}
+}
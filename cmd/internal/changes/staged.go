@@ -0,0 +1,185 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/fixpatch"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/git"
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+)
+
+// StagedFiles returns the paths (relative to the provided project directory) of the files in the project directory
+// that differ between HEAD and the git index. Renames are reported as a deletion and an addition.
+func StagedFiles(projectDir string) ([]string, error) {
+	output, err := git.Output(projectDir, "diff", "--cached", "--name-only", "--no-renames", "--relative", "--")
+	if err != nil {
+		return nil, err
+	}
+	return parseFileList(output), nil
+}
+
+// MaterializeIndex writes the content of the project directory as it will be committed to a new temporary directory, so
+// that the staged content can be linted without being affected by unstaged changes to the staged files. The project
+// directory is copied in the same manner as for a dry run of the fixes (see fixpatch.CopyProject, which honors the
+// provided excludes and skips the provided directories), so files that are not tracked by git (such as generated
+// files) are still available, and then the staged files (see StagedFiles) are overwritten with their content in the
+// git index, or removed if they are deleted in the index. The cost is therefore proportional to the size of the
+// project directory that is linted and the size of the staged changes rather than to the size of the repository.
+// Returns the directory in the temporary directory that corresponds to the project directory and the temporary
+// directory itself, which should be removed by the caller when it is no longer needed.
+func MaterializeIndex(
+	projectDir string,
+	excludes matcher.Matcher,
+	skipDirs []string,
+) (overlayProjectDir string, overlayDir string, rErr error) {
+	absProjectDir, err := resolvedAbsPath(projectDir)
+	if err != nil {
+		return "", "", err
+	}
+	stagedFiles, err := StagedFiles(absProjectDir)
+	if err != nil {
+		return "", "", err
+	}
+	stagedEntries, err := indexEntries(absProjectDir, stagedFiles)
+	if err != nil {
+		return "", "", err
+	}
+
+	overlayProjectDir, overlayDir, err = fixpatch.CopyProject(absProjectDir, excludes, skipDirs)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to copy project directory for staged content")
+	}
+	defer func() {
+		if rErr != nil {
+			_ = os.RemoveAll(overlayDir)
+		}
+	}()
+	// resolve symbolic links so that the directories reported by "go list" in the overlay match
+	if overlayProjectDir, err = resolvedAbsPath(overlayProjectDir); err != nil {
+		return "", "", err
+	}
+
+	for _, stagedFile := range stagedFiles {
+		dst := filepath.Join(overlayProjectDir, filepath.FromSlash(stagedFile))
+		if linked, err := inLinkedDir(overlayProjectDir, stagedFile); err != nil {
+			return "", "", err
+		} else if linked {
+			// excluded directories are links to the project directory, which must not be modified
+			continue
+		}
+		// the file in the copy may be a hard link to the file in the project directory, so it is removed rather than
+		// overwritten
+		if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return "", "", errors.Wrapf(err, "failed to remove %s", dst)
+		}
+		entry, ok := stagedEntries[stagedFile]
+		if !ok {
+			// the file is deleted in the index
+			continue
+		}
+		content, err := git.Output(absProjectDir, "cat-file", "blob", entry.object)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to read staged content of %s", stagedFile)
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", "", errors.Wrapf(err, "failed to create directory for %s", stagedFile)
+		}
+		if err := os.WriteFile(dst, []byte(content), entry.perm); err != nil {
+			return "", "", errors.Wrapf(err, "failed to write staged content of %s", stagedFile)
+		}
+	}
+	return overlayProjectDir, overlayDir, nil
+}
+
+// indexEntry is a regular file in the git index.
+type indexEntry struct {
+	object string
+	perm   os.FileMode
+}
+
+// indexEntries returns the entries in the git index for the provided paths (relative to the provided project
+// directory, in slash form) keyed by path. Paths that are not in the index (because they are deleted) and entries that
+// are not regular files (such as symbolic links and submodules) are not included.
+func indexEntries(projectDir string, paths []string) (map[string]indexEntry, error) {
+	entries := make(map[string]indexEntry)
+	if len(paths) == 0 {
+		return entries, nil
+	}
+	output, err := git.Output(projectDir, append([]string{"ls-files", "--stage", "-z", "--"}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(output, "\x00") {
+		// each entry has the form "<mode> <object> <stage>\t<path>"
+		info, path, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) != 3 {
+			continue
+		}
+		var perm os.FileMode
+		switch fields[0] {
+		case "100644":
+			perm = 0644
+		case "100755":
+			perm = 0755
+		default:
+			continue
+		}
+		entries[path] = indexEntry{
+			object: fields[1],
+			perm:   perm,
+		}
+	}
+	return entries, nil
+}
+
+// inLinkedDir returns true if any of the directories that contain the provided path (relative to the provided
+// directory, in slash form) is a symbolic link.
+func inLinkedDir(dir, path string) (bool, error) {
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		info, err := os.Lstat(filepath.Join(dir, filepath.Join(parts[:i]...)))
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to stat directory of %s", path)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PackagesChangedInIndex returns the directories of the packages in the provided overlay of the project directory
+// (see MaterializeIndex) that are affected by the changes that are staged in the provided project directory (see
+// AffectedPackages). The provided environment variables (in the form "key=value") are added to the environment of the
+// go command that lists the packages.
+func PackagesChangedInIndex(projectDir, overlayProjectDir string, env []string) ([]string, bool, error) {
+	stagedFiles, err := StagedFiles(projectDir)
+	if err != nil {
+		return nil, false, err
+	}
+	return affectedPackagesInDir(overlayProjectDir, stagedFiles, env)
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changes
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializeIndex(t *testing.T) {
+	projectDir := t.TempDir()
+	runGit := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = projectDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, string(output))
+	}
+	writeFile := func(path, content string) {
+		path = filepath.Join(projectDir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	runGit("init")
+	writeFile(".gitignore", "generated.go\n")
+	writeFile("main.go", "committed\n")
+	writeFile("deleted.go", "committed\n")
+	writeFile("unchanged.go", "committed\n")
+	runGit("add", ".")
+	runGit("commit", "-m", "initial")
+
+	writeFile("main.go", "staged\n")
+	writeFile("new/new.go", "staged\n")
+	runGit("add", "main.go", "new/new.go")
+	runGit("rm", "-q", "deleted.go")
+	writeFile("main.go", "unstaged\n")
+	writeFile("generated.go", "untracked\n")
+
+	overlayProjectDir, overlayDir, err := MaterializeIndex(projectDir, nil, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(overlayDir)
+	}()
+
+	for path, want := range map[string]string{
+		"main.go":      "staged\n",
+		"new/new.go":   "staged\n",
+		"unchanged.go": "committed\n",
+		"generated.go": "untracked\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(overlayProjectDir, filepath.FromSlash(path)))
+		require.NoError(t, err, path)
+		assert.Equal(t, want, string(got), path)
+	}
+	_, err = os.Stat(filepath.Join(overlayProjectDir, "deleted.go"))
+	assert.True(t, os.IsNotExist(err), "deleted file should not exist in overlay")
+
+	// the project directory is not modified
+	got, err := os.ReadFile(filepath.Join(projectDir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "unstaged\n", string(got))
+}
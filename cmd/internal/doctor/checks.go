@@ -0,0 +1,207 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/lintcache"
+	"github.com/palantir/pkg/matcher"
+)
+
+// largeGolangCILintCacheSize is the size above which the golangci-lint cache is reported as a warning.
+const largeGolangCILintCacheSize = 5 << 30
+
+// strayConfigFileNames are the names of the configuration files that golangci-lint discovers automatically.
+var strayConfigFileNames = map[string]struct{}{
+	".golangci.yml":  {},
+	".golangci.yaml": {},
+	".golangci.toml": {},
+	".golangci.json": {},
+}
+
+// CheckGoEnv reports the version of the Go toolchain and the GOFLAGS used in the provided project directory. The
+// provided environment variables (in the form "key=value") are added to the environment of the go command.
+func CheckGoEnv(projectDir string, env []string) Result {
+	result := Result{
+		Name: "go environment",
+	}
+
+	cmd := exec.Command("go", "env", "GOVERSION", "GOFLAGS")
+	cmd.Dir = projectDir
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.Output()
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("failed to run \"go env\": %v", err)
+		result.Fix = "install Go and ensure that the go executable is on the PATH"
+		return result
+	}
+	lines := strings.Split(string(bytes.TrimRight(output, "\n")), "\n")
+	goVersion, goFlags := lines[0], ""
+	if len(lines) > 1 {
+		goFlags = lines[1]
+	}
+	result.Status = StatusPass
+	result.Message = fmt.Sprintf("%s, GOFLAGS=%q", goVersion, goFlags)
+
+	if strings.Contains(goFlags, "-mod=vendor") {
+		if _, err := os.Stat(filepath.Join(projectDir, "vendor", "modules.txt")); err != nil {
+			result.Status = StatusWarn
+			result.Message += "\nGOFLAGS specifies -mod=vendor, but the project does not have a vendor/modules.txt file"
+			result.Fix = "run \"go mod vendor\" or remove -mod=vendor from GOFLAGS (for example, in the \"environment\" section of godel/config/godel.yml)"
+		}
+	}
+	return result
+}
+
+// CheckGolangCILintCache reports the location and size of the provided golangci-lint cache directory. If the provided
+// directory is empty, the directory that golangci-lint uses by default (see GolangCILintCacheDir) is reported.
+func CheckGolangCILintCache(cacheDir string) Result {
+	result := Result{
+		Name: "golangci-lint cache",
+	}
+
+	if cacheDir == "" {
+		cacheDir = GolangCILintCacheDir()
+	}
+	if cacheDir == "" {
+		result.Status = StatusWarn
+		result.Message = "could not determine the location of the golangci-lint cache"
+		result.Fix = "set the GOLANGCI_LINT_CACHE environment variable to a writable directory"
+		return result
+	}
+
+	size, err := dirSize(cacheDir)
+	if os.IsNotExist(err) {
+		result.Status = StatusPass
+		result.Message = fmt.Sprintf("%s (does not exist yet)", cacheDir)
+		return result
+	}
+	if err != nil {
+		result.Status = StatusWarn
+		result.Message = fmt.Sprintf("%s: failed to determine size: %v", cacheDir, err)
+		result.Fix = fmt.Sprintf("ensure that %s is readable and writable", cacheDir)
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = fmt.Sprintf("%s (%s)", cacheDir, lintcache.FormatSize(size))
+	if size > largeGolangCILintCacheSize {
+		result.Status = StatusWarn
+		result.Fix = "clean the cache by running \"./godelw cache clean\""
+	}
+	return result
+}
+
+// GolangCILintCacheDir returns the directory used as the golangci-lint cache: the value of the GOLANGCI_LINT_CACHE
+// environment variable if it is set, and the "golangci-lint" directory in the user cache directory otherwise. Returns
+// the empty string if the directory cannot be determined.
+func GolangCILintCacheDir() string {
+	if cacheDir := os.Getenv("GOLANGCI_LINT_CACHE"); cacheDir != "" {
+		return cacheDir
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(userCacheDir, "golangci-lint")
+}
+
+// CheckStrayConfigFiles reports golangci-lint configuration files (such as ".golangci.yml") in the provided project
+// directory. The plugin provides its own configuration to golangci-lint, so these files are ignored when linting using
+// the plugin, but they are used by editors and by golangci-lint when it is run directly. Paths that match the provided
+// excludes are not reported.
+func CheckStrayConfigFiles(projectDir string, excludes matcher.Matcher, ignoreDirs ...string) Result {
+	result := Result{
+		Name: "stray golangci-lint config files",
+	}
+
+	ignored := make(map[string]struct{})
+	for _, dir := range ignoreDirs {
+		ignored[filepath.Clean(dir)] = struct{}{}
+	}
+
+	var strayFiles []string
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if relPath == "." {
+				return nil
+			}
+			if _, ok := ignored[relPath]; ok || d.Name() == ".git" || d.Name() == "vendor" {
+				return filepath.SkipDir
+			}
+			if excludes != nil && excludes.Match(relPath) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, ok := strayConfigFileNames[d.Name()]; ok {
+			strayFiles = append(strayFiles, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		result.Status = StatusWarn
+		result.Message = fmt.Sprintf("failed to search %s: %v", projectDir, err)
+		result.Fix = fmt.Sprintf("ensure that %s is readable", projectDir)
+		return result
+	}
+
+	if len(strayFiles) == 0 {
+		result.Status = StatusPass
+		result.Message = "none found"
+		return result
+	}
+	result.Status = StatusWarn
+	result.Message = fmt.Sprintf("found %d golangci-lint config file(s) that are ignored by the plugin:\n%s", len(strayFiles), strings.Join(strayFiles, "\n"))
+	result.Fix = "move the configuration into godel/config/golangci-lint-plugin.yml and remove these files so that editors and the plugin use the same configuration"
+	return result
+}
+
+func dirSize(dir string) (int64, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return 0, err
+	}
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Status is the outcome of a diagnostic check.
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusWarn Status = "WARN"
+	StatusFail Status = "FAIL"
+)
+
+// Result is the result of running a diagnostic check.
+type Result struct {
+	// Name is a short name for the check (for example, "golangci-lint").
+	Name string
+
+	// Status is the outcome of the check.
+	Status Status
+
+	// Message describes what the check found. May span multiple lines.
+	Message string
+
+	// Fix is a suggested action that resolves the problem found by the check. Should be non-empty if Status is not
+	// StatusPass.
+	Fix string
+}
+
+// Print writes the provided results to the provided writer in a human-readable format and returns the number of
+// results with StatusFail.
+func Print(w io.Writer, results []Result) int {
+	numFailed := 0
+	for _, result := range results {
+		if result.Status == StatusFail {
+			numFailed++
+		}
+		lines := strings.Split(strings.TrimRight(result.Message, "\n"), "\n")
+		_, _ = fmt.Fprintf(w, "[%s] %s: %s\n", result.Status, result.Name, lines[0])
+		for _, line := range lines[1:] {
+			_, _ = fmt.Fprintf(w, "       %s\n", line)
+		}
+		if result.Fix != "" && result.Status != StatusPass {
+			_, _ = fmt.Fprintf(w, "       Fix: %s\n", result.Fix)
+		}
+	}
+	return numFailed
+}
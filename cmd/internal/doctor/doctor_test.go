@@ -0,0 +1,85 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/palantir/pkg/matcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	var buf bytes.Buffer
+	numFailed := Print(&buf, []Result{
+		{
+			Name:    "first",
+			Status:  StatusPass,
+			Message: "ok",
+			Fix:     "not printed for passing checks",
+		},
+		{
+			Name:    "second",
+			Status:  StatusFail,
+			Message: "line 1\nline 2",
+			Fix:     "do something",
+		},
+		{
+			Name:    "third",
+			Status:  StatusWarn,
+			Message: "careful",
+			Fix:     "do something else",
+		},
+	})
+	assert.Equal(t, 1, numFailed)
+	assert.Equal(t, `[PASS] first: ok
+[FAIL] second: line 1
+       line 2
+       Fix: do something
+[WARN] third: careful
+       Fix: do something else
+`, buf.String())
+}
+
+func TestCheckStrayConfigFiles(t *testing.T) {
+	projectDir := t.TempDir()
+	for _, path := range []string{
+		".golangci.yml",
+		"foo/.golangci.toml",
+		"foo/main.go",
+		"excluded/.golangci.yml",
+		"vendor/github.com/bar/.golangci.yaml",
+		"out/golangci-lint/.golangci.yml",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, filepath.Dir(path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, path), []byte("version: \"2\"\n"), 0644))
+	}
+
+	result := CheckStrayConfigFiles(projectDir, matcher.Name("excluded"), "out/golangci-lint")
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "found 2 golangci-lint config file(s) that are ignored by the plugin:\n.golangci.yml\nfoo/.golangci.toml", result.Message)
+
+	result = CheckStrayConfigFiles(projectDir, matcher.Name(`^(foo|excluded)$`), "out/golangci-lint")
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "found 1 golangci-lint config file(s) that are ignored by the plugin:\n.golangci.yml", result.Message)
+
+	require.NoError(t, os.Remove(filepath.Join(projectDir, ".golangci.yml")))
+	result = CheckStrayConfigFiles(projectDir, matcher.Name(`^(foo|excluded)$`), "out/golangci-lint")
+	assert.Equal(t, StatusPass, result.Status)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build golangci_lint_embedded
+
+package embedded
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/golangci/golangci-lint/v2/pkg/commands"
+	"github.com/golangci/golangci-lint/v2/pkg/exitcodes"
+)
+
+// Available is true if golangci-lint is linked into the plugin executable.
+const Available = true
+
+// modulePath is the path of the golangci-lint module.
+const modulePath = "github.com/golangci/golangci-lint/v2"
+
+// Main runs the embedded golangci-lint with the provided arguments (not including the name of the executable) and
+// returns its exit code. The "run" command exits the process itself once it completes.
+func Main(args []string) int {
+	// golangci-lint parses the arguments of the process
+	os.Args = append([]string{"golangci-lint"}, args...)
+	if err := commands.Execute(buildInfo()); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed executing command with error: %v\n", err)
+		return exitcodes.Failure
+	}
+	return exitcodes.Success
+}
+
+// Version returns the version information of the embedded golangci-lint.
+func Version() VersionInfo {
+	info := buildInfo()
+	return VersionInfo{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		Date:      info.Date,
+		GoVersion: info.GoVersion,
+	}
+}
+
+// buildInfo returns the build information reported by the embedded golangci-lint. The version is the version of the
+// golangci-lint module that the plugin was built with.
+func buildInfo() commands.BuildInfo {
+	info := commands.BuildInfo{
+		Version:   "(unknown)",
+		GoVersion: runtime.Version(),
+		Commit:    "(embedded in godel-golangci-lint-plugin)",
+		Date:      "(unknown)",
+	}
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.GoVersion = buildInfo.GoVersion
+	for _, dep := range buildInfo.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		if dep.Version != "" {
+			info.Version = dep.Version
+		}
+		break
+	}
+	return info
+}
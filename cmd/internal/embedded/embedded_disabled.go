@@ -0,0 +1,40 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !golangci_lint_embedded
+
+package embedded
+
+import (
+	"fmt"
+	"os"
+)
+
+// Available is true if golangci-lint is linked into the plugin executable.
+const Available = false
+
+// exitCodeFailure is the exit code of golangci-lint when it fails to run.
+const exitCodeFailure = 3
+
+// Main reports that golangci-lint is not linked into the plugin executable and returns the exit code of a golangci-lint
+// failure.
+func Main(args []string) int {
+	_, _ = fmt.Fprintf(os.Stderr, "golangci-lint is not embedded in this plugin executable: build the plugin with the %q build tag to embed it\n", BuildTag)
+	return exitCodeFailure
+}
+
+// Version returns empty version information, since golangci-lint is not linked into the plugin executable.
+func Version() VersionInfo {
+	return VersionInfo{}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build golangci_lint_embedded
+
+package embedded
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	info := Version()
+	// the version is that of the golangci-lint module in go.mod
+	assert.Regexp(t, `^v2\.\d+\.\d+$`, info.Version)
+	assert.NotEmpty(t, info.GoVersion)
+}
+
+func TestMainVersion(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	assert.Equal(t, 0, Main([]string{"--version"}))
+}
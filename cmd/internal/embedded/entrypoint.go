@@ -0,0 +1,46 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embedded runs the golangci-lint that is linked into the plugin executable when the plugin is built with the
+// "golangci_lint_embedded" build tag. Linking golangci-lint substantially increases the size of the plugin executable,
+// so it is not linked by default: without the build tag, Available is false and Main fails.
+//
+// golangci-lint exits the process when a run completes and writes directly to the standard output and error of the
+// process, so it cannot be run in the process of the plugin command that invokes it (the plugin must remove its
+// temporary files and process the output of golangci-lint afterwards). Instead, the plugin executable is run again with
+// EntrypointArg as its first argument, which makes it behave exactly like a golangci-lint executable (see Main). Using
+// the embedded golangci-lint therefore starts the same number of processes as using a golangci-lint executable: it
+// only removes the need to provide one.
+package embedded
+
+// BuildTag is the build tag that links golangci-lint into the plugin executable.
+const BuildTag = "golangci_lint_embedded"
+
+// EntrypointArg is the first argument of the plugin executable that makes it run the embedded golangci-lint with the
+// remaining arguments.
+const EntrypointArg = "__golangci-lint"
+
+// IsEntrypoint returns true if the provided process arguments (including the name of the executable) invoke the
+// embedded golangci-lint.
+func IsEntrypoint(args []string) bool {
+	return len(args) > 1 && args[1] == EntrypointArg
+}
+
+// VersionInfo describes the embedded golangci-lint.
+type VersionInfo struct {
+	Version   string
+	Commit    string
+	Date      string
+	GoVersion string
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedded
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEntrypoint(t *testing.T) {
+	for i, tc := range []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{
+			name: "entrypoint argument",
+			args: []string{"plugin", EntrypointArg, "run"},
+			want: true,
+		},
+		{
+			name: "plugin command",
+			args: []string{"plugin", "lint", EntrypointArg},
+		},
+		{
+			name: "no arguments",
+			args: []string{"plugin"},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			assert.Equal(t, tc.want, IsEntrypoint(tc.args))
+		})
+	}
+}
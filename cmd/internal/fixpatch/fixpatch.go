@@ -0,0 +1,191 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fixpatch applies the fixes of golangci-lint to a copy of a project and produces a patch of the changes, so
+// that the fixes can be proposed without modifying the project.
+package fixpatch
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+)
+
+// CopyProject copies the provided project directory to a new temporary directory so that golangci-lint can apply its
+// fixes to the copy. golangci-lint only modifies Go files, so only the Go files are copied: other files are hard linked
+// to the files in the project directory when possible (and copied otherwise). Directories that match the provided
+// excludes (such as the godel excludes) are not linted, so they are linked to the directory in the project directory
+// using a symbolic link rather than copied, which still allows packages in them to be imported. The ".git" directory
+// and the provided directories (relative to the project directory, such as the godel output directory) are skipped.
+// Symbolic links to files are copied as regular files so that changes to the copy never modify the files they point
+// to, and symbolic links to directories are skipped. Returns the directory in the temporary directory that corresponds
+// to the project directory and the temporary directory, which the caller must remove.
+func CopyProject(projectDir string, excludes matcher.Matcher, skipDirs []string) (copyProjectDir string, copyDir string, rErr error) {
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to determine absolute path of project directory %s", projectDir)
+	}
+	copyDir, err = os.MkdirTemp("", "golangci-lint-plugin-fix-*")
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to create temporary directory")
+	}
+	defer func() {
+		if rErr != nil {
+			_ = os.RemoveAll(copyDir)
+		}
+	}()
+	copyProjectDir = filepath.Join(copyDir, filepath.Base(absProjectDir))
+
+	skip := make(map[string]bool)
+	for _, dir := range skipDirs {
+		skip[filepath.Clean(dir)] = true
+	}
+	err = filepath.WalkDir(absProjectDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(absProjectDir, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(copyProjectDir, relPath)
+		switch {
+		case entry.IsDir():
+			if relPath == "." {
+				return os.MkdirAll(dst, 0755)
+			}
+			if entry.Name() == ".git" || skip[relPath] {
+				return filepath.SkipDir
+			}
+			if excludes != nil && excludes.Match(relPath) {
+				if err := os.Symlink(path, dst); err != nil {
+					return err
+				}
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(dst, 0755)
+		case excludes != nil && excludes.Match(relPath):
+			return nil
+		case entry.Type()&fs.ModeSymlink != 0:
+			info, err := os.Stat(path)
+			if err != nil || !info.Mode().IsRegular() {
+				// broken links and links to directories are not copied
+				return nil
+			}
+			return copyFile(path, dst, info.Mode().Perm())
+		case entry.Type().IsRegular():
+			// files that are not modified by golangci-lint are hard linked rather than symbolically linked because
+			// files embedded using "go:embed" must be regular files
+			if !strings.HasSuffix(entry.Name(), ".go") && os.Link(path, dst) == nil {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			return copyFile(path, dst, info.Mode().Perm())
+		}
+		return nil
+	})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to copy project directory %s", projectDir)
+	}
+	return copyProjectDir, copyDir, nil
+}
+
+// WriteDiff writes a unified diff of the Go files in the provided copy of the project directory that differ from the
+// corresponding files in the project directory to the provided writer and returns the paths of those files (relative
+// to the project directory, in slash form). The paths in the diff are prefixed with "a/" and "b/" (and files that do
+// not exist in the project directory are diffed against "/dev/null"), so the diff can be applied in the project
+// directory using "patch -p1" or "git apply". Only Go files are compared because golangci-lint only fixes Go files.
+// Symbolic links to directories (see CopyProject) are not followed.
+func WriteDiff(w io.Writer, projectDir, copyProjectDir string) ([]string, error) {
+	var changedFiles []string
+	err := filepath.WalkDir(copyProjectDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), ".go") {
+			return nil
+		}
+		relPath, err := filepath.Rel(copyProjectDir, path)
+		if err != nil {
+			return err
+		}
+		fixed, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		slashPath := filepath.ToSlash(relPath)
+		original, err := os.ReadFile(filepath.Join(projectDir, relPath))
+		var diff string
+		switch {
+		case os.IsNotExist(err):
+			diff = newFileDiff(slashPath, string(fixed))
+		case err != nil:
+			return err
+		case bytes.Equal(original, fixed):
+			return nil
+		default:
+			edits := myers.ComputeEdits(span.URIFromPath(slashPath), string(original), string(fixed))
+			diff = fmt.Sprint(gotextdiff.ToUnified("a/"+slashPath, "b/"+slashPath, string(original), edits))
+		}
+		if _, err := io.WriteString(w, diff); err != nil {
+			return err
+		}
+		changedFiles = append(changedFiles, slashPath)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compare fixed files with project directory %s", projectDir)
+	}
+	return changedFiles, nil
+}
+
+// newFileDiff returns a unified diff that creates the file at the provided path (in slash form) with the provided
+// content. The diff is against "/dev/null" with a hunk that starts at line 0, which is the form that "git apply"
+// requires to create a file.
+func newFileDiff(slashPath, content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	var diff strings.Builder
+	_, _ = fmt.Fprintf(&diff, "--- /dev/null\n+++ b/%s\n@@ -0,0 +1,%d @@\n", slashPath, len(lines))
+	for _, line := range lines {
+		diff.WriteString("+" + line)
+	}
+	if !strings.HasSuffix(content, "\n") && content != "" {
+		diff.WriteString("\n\\ No newline at end of file\n")
+	}
+	return diff.String()
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, content, perm)
+}
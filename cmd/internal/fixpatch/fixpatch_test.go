@@ -0,0 +1,145 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixpatch
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/palantir/pkg/matcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyProject(t *testing.T) {
+	projectDir := t.TempDir()
+	outsideDir := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":                       "module example.com/foo\n",
+		"foo.go":                       "package foo\n",
+		"bar/bar.go":                   "package bar\n",
+		".git/HEAD":                    "ref: refs/heads/main\n",
+		"out/golangci-lint/config.yml": "version: \"2\"\n",
+		"out/build/foo":                "binary\n",
+		"excluded/excluded.go":         "package excluded\n",
+		"assets/data.txt":              "data\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, filepath.Dir(path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, path), []byte(content), 0644))
+	}
+	outsideFile := filepath.Join(outsideDir, "outside.go")
+	require.NoError(t, os.WriteFile(outsideFile, []byte("package foo\n"), 0644))
+	require.NoError(t, os.Symlink(outsideFile, filepath.Join(projectDir, "linked.go")))
+	require.NoError(t, os.Symlink(outsideDir, filepath.Join(projectDir, "linkeddir")))
+
+	copyProjectDir, copyDir, err := CopyProject(projectDir, matcher.Name("excluded"), []string{"out"})
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(copyDir)
+	}()
+	assert.Equal(t, filepath.Join(copyDir, filepath.Base(projectDir)), copyProjectDir)
+
+	for _, path := range []string{"go.mod", "foo.go", "bar/bar.go", "assets/data.txt", "excluded/excluded.go"} {
+		content, err := os.ReadFile(filepath.Join(copyProjectDir, path))
+		require.NoError(t, err)
+		wantContent, err := os.ReadFile(filepath.Join(projectDir, path))
+		require.NoError(t, err)
+		assert.Equal(t, string(wantContent), string(content), path)
+	}
+	assert.NoDirExists(t, filepath.Join(copyProjectDir, ".git"))
+	assert.NoDirExists(t, filepath.Join(copyProjectDir, "out"))
+
+	// Go files are copied and other files are regular files (hard links or copies) so that they can be embedded
+	for _, path := range []string{"foo.go", "assets/data.txt"} {
+		info, err := os.Lstat(filepath.Join(copyProjectDir, path))
+		require.NoError(t, err)
+		assert.True(t, info.Mode().IsRegular(), path)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(copyProjectDir, "foo.go"), []byte("package bar\n"), 0644))
+	content, err := os.ReadFile(filepath.Join(projectDir, "foo.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package foo\n", string(content))
+
+	// excluded directories are linked rather than copied
+	target, err := os.Readlink(filepath.Join(copyProjectDir, "excluded"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectDir, "excluded"), target)
+	assert.NoFileExists(t, filepath.Join(copyProjectDir, "linkeddir"))
+
+	// a link to a file is copied as a regular file, so changing the copy does not change the file it links to
+	info, err := os.Lstat(filepath.Join(copyProjectDir, "linked.go"))
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+	require.NoError(t, os.WriteFile(filepath.Join(copyProjectDir, "linked.go"), []byte("package bar\n"), 0644))
+	content, err = os.ReadFile(outsideFile)
+	require.NoError(t, err)
+	assert.Equal(t, "package foo\n", string(content))
+}
+
+func TestWriteDiff(t *testing.T) {
+	projectDir := t.TempDir()
+	copyProjectDir := t.TempDir()
+	for path, contents := range map[string][2]string{
+		"foo.go":       {"package foo\n\n// Foo does things\nfunc Foo() {}\n", "package foo\n\n// Foo does things.\nfunc Foo() {}\n"},
+		"bar/bar.go":   {"package bar\n", "package bar\n"},
+		"baz/baz.go":   {"package baz\nvar x = 1", "package baz\n\nvar x = 1\n"},
+		"notes.txt":    {"a\n", "b\n"},
+		"unchanged.go": {"package foo\n", "package foo\n"},
+	} {
+		for i, dir := range []string{projectDir, copyProjectDir} {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(contents[i]), 0644))
+		}
+	}
+
+	// a file that only exists in the copy is diffed against /dev/null
+	require.NoError(t, os.WriteFile(filepath.Join(copyProjectDir, "new.go"), []byte("package foo\n"), 0644))
+
+	var patch bytes.Buffer
+	changedFiles, err := WriteDiff(&patch, projectDir, copyProjectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"baz/baz.go", "foo.go", "new.go"}, changedFiles)
+	assert.Equal(t, `--- a/baz/baz.go
++++ b/baz/baz.go
+@@ -1,2 +1,3 @@
+ package baz
+-var x = 1
+\ No newline at end of file
++
++var x = 1
+--- a/foo.go
++++ b/foo.go
+@@ -1,4 +1,4 @@
+ package foo
+ 
+-// Foo does things
++// Foo does things.
+ func Foo() {}
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,1 @@
++package foo
+`, patch.String())
+
+	// the patch applies to the project directory using "git apply"
+	cmd := exec.Command("git", "apply", "--check", "-")
+	cmd.Dir = projectDir
+	cmd.Stdin = &patch
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package githook installs the git pre-commit hook that lints the staged changes of a project.
+package githook
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/git"
+	"github.com/pkg/errors"
+)
+
+// hookMarker is a line in the hooks written by this package that is used to identify them, so that they can be
+// overwritten without requiring the hook to be forced.
+const hookMarker = "# Installed by godel-golangci-lint-plugin."
+
+// Script returns the content of a pre-commit hook that lints the staged changes of the project in the provided
+// directory, which is relative to the root of the repository.
+func Script(relProjectDir string) string {
+	projectDirExpr := `"$(git rev-parse --show-toplevel)"`
+	if relProjectDir != "." {
+		projectDirExpr = fmt.Sprintf(`"$(git rev-parse --show-toplevel)/%s"`, filepath.ToSlash(relProjectDir))
+	}
+	return fmt.Sprintf(`#!/bin/sh
+%s
+# Lints the staged changes before they are committed. Remove this file to disable the hook.
+cd %s || exit 1
+exec ./godelw lint --staged
+`, hookMarker, projectDirExpr)
+}
+
+// Install writes the pre-commit hook returned by Script to the hooks directory of the repository that contains the
+// provided project directory (which respects the "core.hooksPath" git configuration) and returns the path of the hook.
+// If a pre-commit hook that was not written by this package already exists, it is only overwritten if force is true.
+func Install(projectDir string, force bool) (string, error) {
+	topLevel, err := gitOutput(projectDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	relProjectDir, err := relPath(topLevel, projectDir)
+	if err != nil {
+		return "", err
+	}
+
+	hooksDir, err := gitOutput(projectDir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(projectDir, hooksDir)
+	}
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+
+	existing, err := os.ReadFile(hookPath)
+	switch {
+	case err == nil:
+		if !force && !bytes.Contains(existing, []byte(hookMarker)) {
+			return "", errors.Errorf("pre-commit hook %s already exists: use --force to overwrite it", hookPath)
+		}
+	case !os.IsNotExist(err):
+		return "", errors.Wrapf(err, "failed to read existing pre-commit hook")
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", errors.Wrapf(err, "failed to create hooks directory")
+	}
+	if err := os.WriteFile(hookPath, []byte(Script(relProjectDir)), 0755); err != nil {
+		return "", errors.Wrapf(err, "failed to write pre-commit hook")
+	}
+	// the permissions of an existing file are not changed by os.WriteFile
+	if err := os.Chmod(hookPath, 0755); err != nil {
+		return "", errors.Wrapf(err, "failed to make pre-commit hook executable")
+	}
+	return hookPath, nil
+}
+
+// relPath returns the path of the provided project directory relative to the provided repository directory, with
+// symbolic links resolved in both.
+func relPath(repoDir, projectDir string) (string, error) {
+	resolved := make([]string, 0, 2)
+	for _, dir := range []string{repoDir, projectDir} {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to determine absolute path of %s", dir)
+		}
+		if absDir, err = filepath.EvalSymlinks(absDir); err != nil {
+			return "", errors.Wrapf(err, "failed to resolve symbolic links in %s", dir)
+		}
+		resolved = append(resolved, absDir)
+	}
+	rel, err := filepath.Rel(resolved[0], resolved[1])
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to determine path of %s relative to repository %s", projectDir, repoDir)
+	}
+	return rel, nil
+}
+
+// gitOutput returns the output of running git with the provided arguments in the provided directory with leading and
+// trailing whitespace removed.
+func gitOutput(dir string, args ...string) (string, error) {
+	output, err := git.Output(dir, args...)
+	return strings.TrimSpace(output), err
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstall(t *testing.T) {
+	repoDir := t.TempDir()
+	output, err := exec.Command("git", "init", repoDir).CombinedOutput()
+	require.NoError(t, err, string(output))
+	projectDir := filepath.Join(repoDir, "project")
+	require.NoError(t, os.Mkdir(projectDir, 0755))
+	hookPath := filepath.Join(repoDir, ".git", "hooks", "pre-commit")
+
+	gotHookPath, err := Install(projectDir, false)
+	require.NoError(t, err)
+	assert.Equal(t, hookPath, gotHookPath)
+	content, err := os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, Script("project"), string(content))
+	assert.Contains(t, string(content), `cd "$(git rev-parse --show-toplevel)/project" || exit 1`)
+
+	// a hook written by the plugin is overwritten without force
+	_, err = Install(repoDir, false)
+	require.NoError(t, err)
+	content, err = os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, Script("."), string(content))
+
+	// any other hook is only overwritten with force
+	require.NoError(t, os.WriteFile(hookPath, []byte("#!/bin/sh\nexit 0\n"), 0644))
+	_, err = Install(repoDir, false)
+	assert.EqualError(t, err, "pre-commit hook "+hookPath+" already exists: use --force to overwrite it")
+
+	_, err = Install(repoDir, true)
+	require.NoError(t, err)
+	info, err := os.Stat(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
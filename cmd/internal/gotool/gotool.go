@@ -0,0 +1,140 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gotool
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	// GolangCILintToolPath is the package path of the golangci-lint main package that can be declared using a "tool"
+	// directive in a go.mod file.
+	GolangCILintToolPath = "github.com/golangci/golangci-lint/v2/cmd/golangci-lint"
+
+	// golangCILintToolName is the name used to run the golangci-lint tool using "go tool".
+	golangCILintToolName = "golangci-lint"
+)
+
+// HasGolangCILintTool returns true if the go.mod file in the provided directory declares golangci-lint as a tool using
+// a "tool github.com/golangci/golangci-lint/v2/cmd/golangci-lint" directive. Returns false if the directory does not
+// contain a go.mod file.
+func HasGolangCILintTool(projectDir string) (bool, error) {
+	goModPath := filepath.Join(projectDir, "go.mod")
+	goModBytes, err := os.ReadFile(goModPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to read %s", goModPath)
+	}
+	goModFile, err := modfile.Parse(goModPath, goModBytes, nil)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s", goModPath)
+	}
+	for _, tool := range goModFile.Tool {
+		if tool.Path == GolangCILintToolPath {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ResolveGolangCILintTool returns the path to the golangci-lint executable declared as a tool in the go.mod file in
+// the provided directory. If go.mod does not declare golangci-lint as a tool, returns the empty string.
+//
+// The executable is built by the Go toolchain ("go tool -n"), which stores it in the Go build cache so that it is
+// only rebuilt when its inputs change. The build is performed offline: if the project has a vendor directory, the
+// tool is built from the vendored source; otherwise, it is built from the module cache, and it is an error if the
+// required modules are not already in the module cache.
+//
+// The path of the executable is cached on disk (keyed by the inputs of the build, see toolCacheKey), so the go command
+// is only run when the inputs change or the executable no longer exists. The provided environment variables (in the
+// form "key=value") are added to the environment of the go command.
+func ResolveGolangCILintTool(projectDir string, env []string) (string, error) {
+	return resolveGolangCILintTool(projectDir, append(os.Environ(), env...), defaultToolCache())
+}
+
+func resolveGolangCILintTool(projectDir string, environ []string, cache toolCache) (string, error) {
+	if hasTool, err := HasGolangCILintTool(projectDir); err != nil || !hasTool {
+		return "", err
+	}
+
+	env := offlineGoEnv(environ, projectDir)
+	key := toolCacheKey(projectDir, env)
+	if toolPath, ok := cache.load(key); ok {
+		return toolPath, nil
+	}
+
+	cmd := exec.Command("go", "tool", "-n", golangCILintToolName)
+	cmd.Dir = projectDir
+	cmd.Env = env
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "go.mod in %s declares %s as a tool, but building it offline using \"%s\" failed: %s", projectDir, GolangCILintToolPath, strings.Join(cmd.Args, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	toolPath := string(bytes.TrimSpace(output))
+	if toolPath == "" {
+		return "", errors.Errorf("%q did not print the path to the %s executable", strings.Join(cmd.Args, " "), golangCILintToolName)
+	}
+	_ = cache.store(key, toolPath)
+	return toolPath, nil
+}
+
+// offlineGoEnv returns the provided environment modified so that the go command does not access the network. If the
+// provided directory contains a vendor directory, the go command is also configured to use it.
+func offlineGoEnv(environ []string, projectDir string) []string {
+	useVendor := false
+	if _, err := os.Stat(filepath.Join(projectDir, "vendor", "modules.txt")); err == nil {
+		useVendor = true
+	}
+
+	env := make([]string, 0, len(environ)+2)
+	goFlags := ""
+	for _, kv := range environ {
+		switch {
+		case strings.HasPrefix(kv, "GOPROXY="):
+			continue
+		case strings.HasPrefix(kv, "GOFLAGS="):
+			goFlags = strings.TrimPrefix(kv, "GOFLAGS=")
+			continue
+		}
+		env = append(env, kv)
+	}
+	if useVendor {
+		var flags []string
+		for _, flag := range strings.Fields(goFlags) {
+			if strings.HasPrefix(flag, "-mod=") {
+				continue
+			}
+			flags = append(flags, flag)
+		}
+		goFlags = strings.Join(append(flags, "-mod=vendor"), " ")
+	}
+	env = append(env, "GOPROXY=off")
+	if goFlags != "" {
+		env = append(env, "GOFLAGS="+goFlags)
+	}
+	return env
+}
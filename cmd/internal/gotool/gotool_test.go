@@ -0,0 +1,160 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gotool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoModWithTool = "module example.com/foo\n\ngo 1.24.0\n\ntool " + GolangCILintToolPath + "\n"
+
+func TestHasGolangCILintTool(t *testing.T) {
+	for i, tc := range []struct {
+		name  string
+		goMod string
+		want  bool
+	}{
+		{
+			name: "no go.mod",
+		},
+		{
+			name:  "go.mod without tool directive",
+			goMod: "module example.com/foo\n\ngo 1.24.0\n",
+		},
+		{
+			name:  "go.mod with other tool directive",
+			goMod: "module example.com/foo\n\ngo 1.24.0\n\ntool golang.org/x/tools/cmd/stringer\n",
+		},
+		{
+			name:  "go.mod with golangci-lint tool directive",
+			goMod: testGoModWithTool,
+			want:  true,
+		},
+	} {
+		projectDir := t.TempDir()
+		if tc.goMod != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte(tc.goMod), 0644), "Case %d: %s", i, tc.name)
+		}
+		got, err := HasGolangCILintTool(projectDir)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
+
+func Test_offlineGoEnv(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		environ   []string
+		hasVendor bool
+		want      []string
+	}{
+		{
+			name:    "disables proxy",
+			environ: []string{"HOME=/home/user", "GOPROXY=https://proxy.golang.org"},
+			want:    []string{"HOME=/home/user", "GOPROXY=off"},
+		},
+		{
+			name:    "preserves GOFLAGS",
+			environ: []string{"GOFLAGS=-mod=mod -trimpath"},
+			want:    []string{"GOPROXY=off", "GOFLAGS=-mod=mod -trimpath"},
+		},
+		{
+			name:      "uses vendor directory",
+			environ:   []string{"GOFLAGS=-mod=mod -trimpath"},
+			hasVendor: true,
+			want:      []string{"GOPROXY=off", "GOFLAGS=-trimpath -mod=vendor"},
+		},
+	} {
+		projectDir := t.TempDir()
+		if tc.hasVendor {
+			require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "vendor"), 0755), "Case %d: %s", i, tc.name)
+			require.NoError(t, os.WriteFile(filepath.Join(projectDir, "vendor", "modules.txt"), nil, 0644), "Case %d: %s", i, tc.name)
+		}
+		assert.Equal(t, tc.want, offlineGoEnv(tc.environ, projectDir), "Case %d: %s", i, tc.name)
+	}
+}
+
+// writeFakeGo writes an executable script named "go" to a new directory that is used as the PATH for the test. The
+// script records each invocation in the returned invocation log file and prints the path of the returned tool
+// executable.
+func writeFakeGo(t *testing.T) (toolPath, invocationLogPath string) {
+	dir := t.TempDir()
+	toolPath = filepath.Join(dir, "golangci-lint")
+	require.NoError(t, os.WriteFile(toolPath, []byte("#!/bin/sh\n"), 0755))
+	invocationLogPath = filepath.Join(dir, "invocations.log")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %q\necho %q\n", invocationLogPath, toolPath)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go"), []byte(script), 0755))
+	t.Setenv("PATH", dir)
+	return toolPath, invocationLogPath
+}
+
+func numInvocations(t *testing.T, invocationLogPath string) int {
+	content, err := os.ReadFile(invocationLogPath)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return strings.Count(string(content), "tool -n golangci-lint")
+}
+
+func TestResolveGolangCILintToolUsesCache(t *testing.T) {
+	toolPath, invocationLogPath := writeFakeGo(t)
+	projectDir := t.TempDir()
+	goModPath := filepath.Join(projectDir, "go.mod")
+	require.NoError(t, os.WriteFile(goModPath, []byte(testGoModWithTool), 0644))
+	cache := toolCache{dir: filepath.Join(t.TempDir(), "tools")}
+
+	for i := 0; i < 3; i++ {
+		got, err := resolveGolangCILintTool(projectDir, nil, cache)
+		require.NoError(t, err)
+		assert.Equal(t, toolPath, got)
+	}
+	assert.Equal(t, 1, numInvocations(t, invocationLogPath), "go tool should only be run on a cache miss")
+
+	// changing go.mod invalidates the cache entry
+	require.NoError(t, os.WriteFile(goModPath, []byte(testGoModWithTool+"\nrequire example.com/bar v1.0.0\n"), 0644))
+	_, err := resolveGolangCILintTool(projectDir, nil, cache)
+	require.NoError(t, err)
+	assert.Equal(t, 2, numInvocations(t, invocationLogPath))
+
+	// changing an environment variable that affects the build invalidates the cache entry
+	_, err = resolveGolangCILintTool(projectDir, []string{"GOFLAGS=-trimpath"}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, 3, numInvocations(t, invocationLogPath))
+
+	// a cache entry for an executable that no longer exists is ignored
+	require.NoError(t, os.Remove(toolPath))
+	_, err = resolveGolangCILintTool(projectDir, []string{"GOFLAGS=-trimpath"}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, 4, numInvocations(t, invocationLogPath))
+}
+
+func TestResolveGolangCILintToolWithoutTool(t *testing.T) {
+	_, invocationLogPath := writeFakeGo(t)
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/foo\n\ngo 1.24.0\n"), 0644))
+
+	got, err := resolveGolangCILintTool(projectDir, nil, toolCache{})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, 0, numInvocations(t, invocationLogPath), "go tool should not be run if no tool is declared")
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gotool
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// toolCacheDirEnvVar is the environment variable that overrides the directory of the asset cache of the plugin. The
+// tool cache is stored in the "tools" directory of that directory, and is disabled if the variable is set to the empty
+// string.
+const toolCacheDirEnvVar = "GODEL_GOLANGCI_LINT_PLUGIN_ASSET_CACHE_DIR"
+
+// toolCacheKeyEnvVars are the environment variables that affect the executable built by "go tool -n".
+var toolCacheKeyEnvVars = []string{
+	"CGO_ENABLED",
+	"GOARCH",
+	"GOEXPERIMENT",
+	"GOFLAGS",
+	"GOOS",
+	"GOTOOLCHAIN",
+	"GOWORK",
+}
+
+// toolCache stores the path of the golangci-lint tool executable built by "go tool -n" so that the go command does not
+// need to be run on every invocation of the plugin. Each entry is stored in its own file whose name is the key derived
+// from the inputs of the build (see toolCacheKey). The zero value is a disabled cache.
+type toolCache struct {
+	dir string
+}
+
+// defaultToolCache returns the cache stored in the "tools" directory of the directory specified by the
+// toolCacheDirEnvVar environment variable if it is set, and the "godel-golangci-lint-plugin/tools" directory in the
+// user cache directory otherwise. Returns a disabled cache if the user cache directory cannot be determined.
+func defaultToolCache() toolCache {
+	if dir, ok := os.LookupEnv(toolCacheDirEnvVar); ok {
+		if dir == "" {
+			return toolCache{}
+		}
+		return toolCache{dir: filepath.Join(dir, "tools")}
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return toolCache{}
+	}
+	return toolCache{dir: filepath.Join(userCacheDir, "godel-golangci-lint-plugin", "tools")}
+}
+
+// load returns the cached path of the executable for the provided key. Returns false if the cache is disabled, if there
+// is no entry for the key or if the executable no longer exists (for example, because the Go build cache was trimmed).
+func (c toolCache) load(key string) (string, bool) {
+	if c.dir == "" || key == "" {
+		return "", false
+	}
+	pathBytes, err := os.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return "", false
+	}
+	toolPath := string(pathBytes)
+	if fi, err := os.Stat(toolPath); err != nil || fi.IsDir() {
+		return "", false
+	}
+	return toolPath, true
+}
+
+// store writes the provided executable path to the cache for the provided key. The entry is written to a temporary
+// file that is then renamed so that readers never observe a partially written entry. Storing an entry in a disabled
+// cache is a no-op.
+func (c toolCache) store(key, toolPath string) error {
+	if c.dir == "" || key == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create tool cache directory %s", c.dir)
+	}
+	tmpFile, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary tool cache entry")
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+	if _, err := tmpFile.WriteString(toolPath); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to write tool cache entry")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close tool cache entry")
+	}
+	if err := os.Rename(tmpFile.Name(), filepath.Join(c.dir, key)); err != nil {
+		return errors.Wrapf(err, "failed to write tool cache entry")
+	}
+	return nil
+}
+
+// toolCacheKey returns the key of the tool cache entry for the golangci-lint tool of the project in the provided
+// directory built using the provided environment. The key is derived from the inputs that determine the executable
+// built by "go tool -n": the project directory, the content of its go.mod, go.sum and vendor/modules.txt files and of
+// the go.work file that applies to it, the go executable and the environment variables that affect the build. Returns
+// the empty string (which disables caching) if the go executable cannot be found.
+func toolCacheKey(projectDir string, env []string) string {
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return ""
+	}
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		return ""
+	}
+	goInfo, err := os.Stat(goPath)
+	if err != nil {
+		return ""
+	}
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\x00%s\x00%d\x00%d\x00", absProjectDir, goPath, goInfo.Size(), goInfo.ModTime().UnixNano())
+	envValues := make(map[string]string)
+	for _, kv := range env {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			envValues[name] = value
+		}
+	}
+	for _, name := range toolCacheKeyEnvVars {
+		_, _ = fmt.Fprintf(hash, "%s=%s\x00", name, envValues[name])
+	}
+	files := []string{
+		filepath.Join(absProjectDir, "go.mod"),
+		filepath.Join(absProjectDir, "go.sum"),
+		filepath.Join(absProjectDir, "vendor", "modules.txt"),
+	}
+	if goWork := findGoWork(absProjectDir); goWork != "" {
+		files = append(files, goWork, goWork+".sum")
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			return ""
+		}
+		_, _ = fmt.Fprintf(hash, "%s\x00%d\x00", file, len(content))
+		_, _ = hash.Write(content)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// findGoWork returns the path of the go.work file in the provided directory or the closest of its parent directories
+// that contains one. Returns the empty string if there is no such file.
+func findGoWork(dir string) string {
+	for {
+		goWork := filepath.Join(dir, "go.work")
+		if fi, err := os.Stat(goWork); err == nil && !fi.IsDir() {
+			return goWork
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
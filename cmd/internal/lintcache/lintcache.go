@@ -0,0 +1,195 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lintcache manages the directory that golangci-lint uses as its cache. The plugin uses a separate cache for
+// each golangci-lint executable so that results cached by one version of golangci-lint are never used by another, and
+// uses file locks so that a cache is not cleaned while golangci-lint is using it.
+package lintcache
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/pkg/errors"
+)
+
+// EnvVar is the environment variable that specifies the directory that golangci-lint uses as its cache.
+const EnvVar = "GOLANGCI_LINT_CACHE"
+
+// lockRetryDelay is the delay between attempts to acquire the lock of a cache.
+const lockRetryDelay = 100 * time.Millisecond
+
+// Cache is a golangci-lint cache directory.
+type Cache struct {
+	// Dir is the directory that golangci-lint uses as its cache.
+	Dir string
+}
+
+// Status describes the content of a cache.
+type Status struct {
+	// Size is the total size in bytes of the files in the cache.
+	Size int64
+
+	// Entries is the number of files in the cache.
+	Entries int
+}
+
+var invalidKeyCharsRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Key returns the name of the cache directory for the golangci-lint executable with the provided version, Go version
+// and SHA-256 checksum (any of which may be empty). The checksum distinguishes executables that report the same
+// version (for example, custom builds).
+func Key(version, goVersion, sha256 string) string {
+	parts := []string{"golangci-lint"}
+	for _, part := range []string{version, goVersion} {
+		if part != "" {
+			parts = append(parts, invalidKeyCharsRegexp.ReplaceAllString(part, "_"))
+		}
+	}
+	if len(sha256) > 12 {
+		sha256 = sha256[:12]
+	}
+	if sha256 != "" {
+		parts = append(parts, sha256)
+	}
+	return strings.Join(parts, "-")
+}
+
+// New returns the cache for the golangci-lint executable with the provided key (see Key) in the provided root
+// directory.
+func New(rootDir, key string) Cache {
+	return Cache{
+		Dir: filepath.Join(rootDir, key),
+	}
+}
+
+// RLock acquires a shared lock on the cache, which is held while golangci-lint uses the cache, and returns a function
+// that releases it. Any number of shared locks can be held at the same time, but not while an exclusive lock (see
+// Clean) is held: if the cache is being cleaned, RLock waits until cleaning is complete or the context is done.
+func (c Cache) RLock(ctx context.Context) (func(), error) {
+	return c.lock(ctx, true)
+}
+
+// Clean removes the content of the cache. An exclusive lock on the cache is held while it is removed, so Clean waits
+// until golangci-lint runs that use the cache (that hold a shared lock) have completed or the context is done.
+func (c Cache) Clean(ctx context.Context) error {
+	unlock, err := c.lock(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return errors.Wrapf(err, "failed to remove cache directory %s", c.Dir)
+	}
+	return nil
+}
+
+// Status returns the size and the number of entries of the cache. Returns an empty Status if the cache directory does
+// not exist.
+func (c Cache) Status() (Status, error) {
+	var status Status
+	err := filepath.WalkDir(c.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		status.Size += info.Size()
+		status.Entries++
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return Status{}, errors.Wrapf(err, "failed to read cache directory %s", c.Dir)
+	}
+	return status, nil
+}
+
+// lockPath returns the path of the lock file of the cache. The lock file is outside of the cache directory so that the
+// directory can be removed while the lock is held.
+func (c Cache) lockPath() string {
+	return filepath.Clean(c.Dir) + ".lock"
+}
+
+func (c Cache) lock(ctx context.Context, shared bool) (func(), error) {
+	lockPath := c.lockPath()
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory for cache lock %s", lockPath)
+	}
+	fileLock := flock.New(lockPath)
+	tryLock := fileLock.TryLockContext
+	if shared {
+		tryLock = fileLock.TryRLockContext
+	}
+	locked, err := tryLock(ctx, lockRetryDelay)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to acquire cache lock %s", lockPath)
+	}
+	if !locked {
+		return nil, errors.Errorf("failed to acquire cache lock %s", lockPath)
+	}
+	return func() {
+		_ = fileLock.Unlock()
+	}, nil
+}
+
+// List returns the caches in the provided root directory (the directories created by the plugin for different
+// golangci-lint executables), sorted by directory. Returns an empty slice if the root directory does not exist.
+func List(rootDir string) ([]Cache, error) {
+	entries, err := os.ReadDir(rootDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read cache root directory %s", rootDir)
+	}
+	var caches []Cache
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), "golangci-lint-") {
+			caches = append(caches, Cache{
+				Dir: filepath.Join(rootDir, entry.Name()),
+			})
+		}
+	}
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].Dir < caches[j].Dir
+	})
+	return caches, nil
+}
+
+// FormatSize returns the provided size in bytes in a human-readable form.
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lintcache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		version   string
+		goVersion string
+		sha256    string
+		want      string
+	}{
+		{
+			name:      "all components",
+			version:   "v2.1.6",
+			goVersion: "go1.24.2",
+			sha256:    "0123456789abcdef0123456789abcdef",
+			want:      "golangci-lint-v2.1.6-go1.24.2-0123456789ab",
+		},
+		{
+			name:    "missing components are omitted and invalid characters are replaced",
+			version: "v2.1.6+custom build",
+			want:    "golangci-lint-v2.1.6_custom_build",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			assert.Equal(t, tc.want, Key(tc.version, tc.goVersion, tc.sha256))
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	rootDir := t.TempDir()
+	cache := New(rootDir, Key("v2.1.6", "go1.24.2", ""))
+	otherCache := New(rootDir, Key("v2.1.5", "go1.24.2", ""))
+
+	status, err := cache.Status()
+	require.NoError(t, err)
+	assert.Equal(t, Status{}, status)
+
+	for _, c := range []Cache{cache, otherCache} {
+		require.NoError(t, os.MkdirAll(filepath.Join(c.Dir, "00"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(c.Dir, "00", "entry-a"), []byte("12345"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(c.Dir, "entry-b"), []byte("123"), 0644))
+	}
+	status, err = cache.Status()
+	require.NoError(t, err)
+	assert.Equal(t, Status{Size: 8, Entries: 2}, status)
+
+	caches, err := List(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []Cache{otherCache, cache}, caches)
+
+	// the cache cannot be cleaned while a shared lock is held
+	unlock, err := cache.RLock(context.Background())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	assert.Error(t, cache.Clean(ctx))
+	unlock()
+
+	require.NoError(t, cache.Clean(context.Background()))
+	_, err = os.Stat(cache.Dir)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(otherCache.Dir)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modules discovers the Go modules and the Go workspace in a project directory so that all of the modules of
+// the project can be linted.
+package modules
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+// Module is a Go module in a project directory.
+type Module struct {
+	// Dir is the directory that contains the go.mod file of the module, relative to the project directory ("." for a
+	// module in the project directory itself).
+	Dir string
+
+	// Path is the module path declared in the go.mod file.
+	Path string
+}
+
+// Discover returns the modules in the provided project directory, which are the directories that contain a go.mod
+// file. Directories that match the provided excludes are skipped, as are the directories that the go command ignores
+// (vendor and testdata directories and directories whose names begin with "." or "_"). The module in the project
+// directory (if any) is returned first, followed by the other modules sorted by directory.
+func Discover(projectDir string, excludes matcher.Matcher) ([]Module, error) {
+	var modules []Module
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return err
+		}
+		if relPath != "." {
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if excludes != nil && excludes.Match(relPath) {
+				return filepath.SkipDir
+			}
+		}
+
+		goModPath := filepath.Join(path, "go.mod")
+		goModContent, err := os.ReadFile(goModPath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to read %s", goModPath)
+		}
+		modulePath := modfile.ModulePath(goModContent)
+		if modulePath == "" {
+			return errors.Errorf("%s does not declare a module path", goModPath)
+		}
+		modules = append(modules, Module{
+			Dir:  filepath.ToSlash(relPath),
+			Path: modulePath,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover modules in %s", projectDir)
+	}
+	sort.SliceStable(modules, func(i, j int) bool {
+		if modules[i].Dir == "." || modules[j].Dir == "." {
+			return modules[i].Dir == "."
+		}
+		return modules[i].Dir < modules[j].Dir
+	})
+	return modules, nil
+}
+
+// RelativeExcludes returns the provided godel excludes (which are relative to the project directory) as excludes
+// relative to the provided module directory (which is relative to the project directory). Names are matched in any
+// directory, so they are returned as-is. Paths within the module directory are made relative to it, and other paths are
+// dropped because they cannot match any file in the module (modules in excluded directories are not returned by
+// Discover).
+func RelativeExcludes(excludes matcher.NamesPathsCfg, moduleDir string) matcher.NamesPathsCfg {
+	if moduleDir == "." {
+		return excludes
+	}
+	relExcludes := matcher.NamesPathsCfg{
+		Names: excludes.Names,
+	}
+	for _, path := range excludes.Paths {
+		path = filepath.ToSlash(filepath.Clean(path))
+		if strings.HasPrefix(path, moduleDir+"/") {
+			relExcludes.Paths = append(relExcludes.Paths, strings.TrimPrefix(path, moduleDir+"/"))
+		}
+	}
+	return relExcludes
+}
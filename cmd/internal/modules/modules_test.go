@@ -0,0 +1,92 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/palantir/pkg/matcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	projectDir := t.TempDir()
+	for dir, modulePath := range map[string]string{
+		".":              "example.com/root",
+		"tools":          "example.com/tools",
+		"services/b":     "example.com/services/b",
+		"services/a":     "example.com/services/a",
+		"excluded":       "example.com/excluded",
+		"vendor/foo":     "example.com/vendor",
+		"a/testdata/mod": "example.com/testdata",
+		".hidden":        "example.com/hidden",
+		"_ignored":       "example.com/ignored",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, dir), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, dir, "go.mod"), []byte("module "+modulePath+"\n\ngo 1.24\n"), 0644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "services", "nomodule"), 0755))
+
+	got, err := Discover(projectDir, matcher.Name("excluded"))
+	require.NoError(t, err)
+	assert.Equal(t, []Module{
+		{Dir: ".", Path: "example.com/root"},
+		{Dir: "services/a", Path: "example.com/services/a"},
+		{Dir: "services/b", Path: "example.com/services/b"},
+		{Dir: "tools", Path: "example.com/tools"},
+	}, got)
+}
+
+func TestRelativeExcludes(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		excludes  matcher.NamesPathsCfg
+		moduleDir string
+		want      matcher.NamesPathsCfg
+	}{
+		{
+			name: "excludes are unchanged for the project directory",
+			excludes: matcher.NamesPathsCfg{
+				Names: []string{"generated"},
+				Paths: []string{"tools/gen"},
+			},
+			moduleDir: ".",
+			want: matcher.NamesPathsCfg{
+				Names: []string{"generated"},
+				Paths: []string{"tools/gen"},
+			},
+		},
+		{
+			name: "paths in the module are made relative to the module and other paths are dropped",
+			excludes: matcher.NamesPathsCfg{
+				Names: []string{"generated"},
+				Paths: []string{"tools/gen", "tools/a/b", "toolsgen", "other"},
+			},
+			moduleDir: "tools",
+			want: matcher.NamesPathsCfg{
+				Names: []string{"generated"},
+				Paths: []string{"gen", "a/b"},
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			assert.Equal(t, tc.want, RelativeExcludes(tc.excludes, tc.moduleDir))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+// Workspace is a Go workspace defined by a go.work file.
+type Workspace struct {
+	// File is the path of the go.work file.
+	File string
+
+	// Members are the directories of the modules in the workspace (the "use" directives of the go.work file) that are
+	// within the directory that contains the go.work file, relative to that directory ("." for a module in the
+	// directory itself).
+	Members []string
+}
+
+// Dir returns the directory that contains the go.work file, which is the root of the workspace.
+func (w *Workspace) Dir() string {
+	return filepath.Dir(w.File)
+}
+
+// FindWorkspace returns the workspace that the go command uses in the provided directory (as reported by
+// "go env GOWORK", which respects the GOWORK environment variable), or nil if the go command does not use a workspace
+// in the directory.
+func FindWorkspace(dir string) (*Workspace, error) {
+	cmd := exec.Command("go", "env", "GOWORK")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run \"go env GOWORK\": %s", strings.TrimSpace(stderr.String()))
+	}
+	goWorkPath := strings.TrimSpace(string(output))
+	if goWorkPath == "" || goWorkPath == "off" {
+		return nil, nil
+	}
+	return ReadWorkspace(goWorkPath)
+}
+
+// ReadWorkspace reads the workspace defined by the go.work file at the provided path. Modules outside of the directory
+// that contains the go.work file are not members of the returned workspace.
+func ReadWorkspace(goWorkPath string) (*Workspace, error) {
+	content, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", goWorkPath)
+	}
+	workFile, err := modfile.ParseWork(goWorkPath, content, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", goWorkPath)
+	}
+
+	workspace := &Workspace{
+		File: goWorkPath,
+	}
+	for _, use := range workFile.Use {
+		memberDir := filepath.FromSlash(use.Path)
+		if filepath.IsAbs(memberDir) {
+			if memberDir, err = filepath.Rel(workspace.Dir(), memberDir); err != nil {
+				continue
+			}
+		}
+		memberDir = filepath.ToSlash(filepath.Clean(memberDir))
+		if memberDir == ".." || strings.HasPrefix(memberDir, "../") {
+			continue
+		}
+		workspace.Members = append(workspace.Members, memberDir)
+	}
+	return workspace, nil
+}
+
+// MembersToLint returns the members of the workspace that are not matched by the provided excludes (which are
+// relative to the workspace directory) and are not in the provided list of excluded member directories.
+func (w *Workspace) MembersToLint(excludes matcher.Matcher, excludedMembers []string) []string {
+	excluded := make(map[string]struct{})
+	for _, member := range excludedMembers {
+		excluded[filepath.ToSlash(filepath.Clean(member))] = struct{}{}
+	}
+	var members []string
+	for _, member := range w.Members {
+		if _, ok := excluded[member]; ok {
+			continue
+		}
+		if member != "." && excludes != nil && excludes.Match(filepath.FromSlash(member)) {
+			continue
+		}
+		members = append(members, member)
+	}
+	return members
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/palantir/pkg/matcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWorkspace(t *testing.T) {
+	workspaceDir := t.TempDir()
+	goWorkPath := filepath.Join(workspaceDir, "go.work")
+	content := "go 1.24\n\nuse (\n\t.\n\t./tools\n\t./services/a/\n\t../outside\n\t" + filepath.ToSlash(filepath.Join(workspaceDir, "abs")) + "\n)\n"
+	require.NoError(t, os.WriteFile(goWorkPath, []byte(content), 0644))
+
+	workspace, err := ReadWorkspace(goWorkPath)
+	require.NoError(t, err)
+	assert.Equal(t, &Workspace{
+		File:    goWorkPath,
+		Members: []string{".", "tools", "services/a", "abs"},
+	}, workspace)
+	assert.Equal(t, workspaceDir, workspace.Dir())
+
+	assert.Equal(t, []string{".", "abs"}, workspace.MembersToLint(matcher.Name("services"), []string{"./tools"}))
+	assert.Equal(t, []string{"tools", "services/a", "abs"}, workspace.MembersToLint(nil, []string{"."}))
+}
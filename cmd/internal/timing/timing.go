@@ -0,0 +1,361 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DominantShare is the share of the total linter time above which a linter is considered to dominate a run.
+	DominantShare = 0.5
+
+	// goAnalysisMetaLinter is the name of the stage in which golangci-lint runs all of the linters that are
+	// implemented as analyzers. The time of each analyzer is reported separately.
+	goAnalysisMetaLinter = "goanalysis_metalinter"
+
+	// typecheckLinter is the name of the pseudo-linter that reports compilation errors. It cannot be disabled.
+	typecheckLinter = "typecheck"
+)
+
+var (
+	// msgRegexp matches the message of a line logged by golangci-lint in verbose mode, such as
+	// `level=info msg="[runner] linters took 1.2s with stages: goanalysis_metalinter: 1.1s"`.
+	msgRegexp = regexp.MustCompile(`^level=info msg=("(?:[^"\\]|\\.)*")`)
+
+	// coloredMsgRegexp matches the message of a line logged by golangci-lint in verbose mode when its output is colored
+	// (for example, when CLICOLOR_FORCE is set), such as
+	// "\x1b[36mINFO\x1b[0m [runner] linters took 1.2s with stages: goanalysis_metalinter: 1.1s". The message is padded
+	// with trailing spaces.
+	coloredMsgRegexp = regexp.MustCompile(`^\x1b\[\d+mINFO\x1b\[0m (.*?) *$`)
+
+	// stopwatchRegexp matches a message logged by a golangci-lint stopwatch, such as
+	// "[runner] linters took 1.2s with stages: goanalysis_metalinter: 1.1s" or
+	// "[linters_context/goanalysis] analyzers took 3s with top 10 stages: govet: 2s, errcheck: 1s".
+	stopwatchRegexp = regexp.MustCompile(`^(?:\[[^\]]*\] )?(.+?) took (\S+)(?: with (?:no stages|(?:top \d+ )?stages: (.*)))?$`)
+)
+
+// PlainOutputEnv are the environment variables (in the form "key=value") that disable colored output, which should be
+// set for golangci-lint runs whose timing is recorded so that the output is in the format matched by msgRegexp.
+var PlainOutputEnv = []string{"CLICOLOR_FORCE=0", "NO_COLOR=1"}
+
+// Entry is the duration of a phase of a golangci-lint run or of a linter.
+type Entry struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the timing of one or more golangci-lint runs. The durations of multiple runs (for example, one for each
+// module of the project) are summed.
+type Report struct {
+	// Total is the total duration of the golangci-lint runs.
+	Total time.Duration `json:"total"`
+	// Phases are the durations of the phases of the runs (such as loading packages, running linters and processing
+	// issues), sorted by descending duration.
+	Phases []Entry `json:"phases"`
+	// Linters are the durations of the linters, sorted by descending duration. The duration of a linter that is
+	// implemented as an analyzer is the sum of its durations for all packages, so it can exceed the duration of the
+	// "linters" phase when packages are analyzed in parallel. golangci-lint only reports the 10 slowest analyzers.
+	Linters []Entry `json:"linters"`
+}
+
+// Recorder records the timing reported by golangci-lint runs in verbose mode. It is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	total     time.Duration
+	phases    map[string]time.Duration
+	linters   map[string]time.Duration
+	analyzers map[string]time.Duration
+}
+
+// NewRecorder returns a new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		phases:    make(map[string]time.Duration),
+		linters:   make(map[string]time.Duration),
+		analyzers: make(map[string]time.Duration),
+	}
+}
+
+// Writer returns a writer for the verbose output of a single golangci-lint run that records the timing in the output
+// and writes the output to the provided writer. If filterVerbose is true, the informational lines that golangci-lint
+// only writes in verbose mode are not written to the provided writer. The returned writer must be flushed once the
+// run is complete.
+func (r *Recorder) Writer(w io.Writer, filterVerbose bool) *LineWriter {
+	return &LineWriter{
+		recorder:      r,
+		w:             w,
+		filterVerbose: filterVerbose,
+	}
+}
+
+// RecordLine records the timing in the provided line of golangci-lint output (if any) and returns whether the line
+// was logged by golangci-lint at the info level, which is only done in verbose mode.
+func (r *Recorder) RecordLine(line string) bool {
+	msg, ok := infoMessage(line)
+	if !ok {
+		return false
+	}
+	name, duration, stages, ok := parseStopwatch(msg)
+	if !ok {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case name == "Execution":
+		r.total += duration
+	case name == "analyzers":
+		addStages(r.analyzers, stages)
+	case name == "linters":
+		r.phases[name] += duration
+		addStages(r.linters, stages)
+	case strings.HasPrefix(name, "Go packages loading"):
+		r.phases["packages loading"] += duration
+	default:
+		r.phases[name] += duration
+	}
+	return true
+}
+
+// infoMessage returns the message of the provided line if it was logged by golangci-lint at the info level in either
+// the plain or the colored format. The returned message is empty if it cannot be unquoted.
+func infoMessage(line string) (string, bool) {
+	if match := msgRegexp.FindStringSubmatch(line); match != nil {
+		msg, err := strconv.Unquote(match[1])
+		if err != nil {
+			return "", true
+		}
+		return msg, true
+	}
+	if match := coloredMsgRegexp.FindStringSubmatch(line); match != nil {
+		return match[1], true
+	}
+	return "", false
+}
+
+// Report returns the timing recorded so far.
+func (r *Recorder) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linters := make(map[string]time.Duration)
+	for name, duration := range r.linters {
+		// the linters that are implemented as analyzers are reported individually if their durations are known
+		if name == goAnalysisMetaLinter && len(r.analyzers) > 0 {
+			continue
+		}
+		linters[name] += duration
+	}
+	for name, duration := range r.analyzers {
+		linters[name] += duration
+	}
+	return Report{
+		Total:   r.total,
+		Phases:  sortedEntries(r.phases),
+		Linters: sortedEntries(linters),
+	}
+}
+
+// Dominant returns the linter that accounts for more than DominantShare of the total linter time of the report and its
+// share of the total linter time. Returns false if the report has fewer than 2 linters or no linter that can be
+// disabled dominates.
+func (r Report) Dominant() (Entry, float64, bool) {
+	if len(r.Linters) < 2 {
+		return Entry{}, 0, false
+	}
+	var total time.Duration
+	for _, linter := range r.Linters {
+		total += linter.Duration
+	}
+	if total <= 0 {
+		return Entry{}, 0, false
+	}
+	slowest := r.Linters[0]
+	share := float64(slowest.Duration) / float64(total)
+	if slowest.Name == typecheckLinter || share <= DominantShare {
+		return Entry{}, 0, false
+	}
+	return slowest, share, true
+}
+
+// Print writes the report to the provided writer as tables of the phases and linters, followed by a suggestion if a
+// single linter dominates the run.
+func Print(w io.Writer, report Report) {
+	_, _ = fmt.Fprintln(w, "Timing:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  PHASE\tDURATION")
+	for _, phase := range report.Phases {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", phase.Name, formatDuration(phase.Duration))
+	}
+	if report.Total > 0 {
+		_, _ = fmt.Fprintf(tw, "  total\t%s\n", formatDuration(report.Total))
+	}
+	_ = tw.Flush()
+
+	if len(report.Linters) == 0 {
+		_, _ = fmt.Fprintln(w, "No linter timing was reported by golangci-lint.")
+		return
+	}
+	var lintersTotal time.Duration
+	for _, linter := range report.Linters {
+		lintersTotal += linter.Duration
+	}
+	_, _ = fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  LINTER\tDURATION\tSHARE")
+	for _, linter := range report.Linters {
+		share := 0.0
+		if lintersTotal > 0 {
+			share = 100 * float64(linter.Duration) / float64(lintersTotal)
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%.1f%%\n", linter.Name, formatDuration(linter.Duration), share)
+	}
+	_ = tw.Flush()
+
+	if dominant, share, ok := report.Dominant(); ok {
+		_, _ = fmt.Fprintf(w, "\n%s accounts for %.0f%% of the linter time: consider moving it to a profile in the \"profiles\" section of the plugin configuration, which only runs it when the profile is selected using \"./godelw lint --profile <profile>\".\n", dominant.Name, 100*share)
+	}
+}
+
+// WriteJSONFile writes the provided report as JSON to the file at the provided path. Durations are written in
+// nanoseconds.
+func WriteJSONFile(path string, report Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal timing report")
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write timing report")
+	}
+	return nil
+}
+
+// LineWriter is a writer for the output of a single golangci-lint run that records the timing in each complete line
+// of the output using a Recorder.
+type LineWriter struct {
+	recorder      *Recorder
+	w             io.Writer
+	filterVerbose bool
+	buf           []byte
+}
+
+func (lw *LineWriter) Write(p []byte) (int, error) {
+	lw.buf = append(lw.buf, p...)
+	for {
+		i := bytes.IndexByte(lw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := lw.writeLine(lw.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		lw.buf = lw.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush records and writes the last line of the output if it is not terminated by a newline.
+func (lw *LineWriter) Flush() error {
+	if len(lw.buf) == 0 {
+		return nil
+	}
+	line := lw.buf
+	lw.buf = nil
+	return lw.writeLine(line)
+}
+
+func (lw *LineWriter) writeLine(line []byte) error {
+	if lw.recorder.RecordLine(strings.TrimRight(string(line), "\r\n")) && lw.filterVerbose {
+		return nil
+	}
+	_, err := lw.w.Write(line)
+	return err
+}
+
+// parseStopwatch parses a message logged by a golangci-lint stopwatch into the name of the stopwatch, its duration and
+// the durations of its stages.
+func parseStopwatch(msg string) (string, time.Duration, []Entry, bool) {
+	match := stopwatchRegexp.FindStringSubmatch(msg)
+	if match == nil {
+		return "", 0, nil, false
+	}
+	duration, err := time.ParseDuration(match[2])
+	if err != nil {
+		return "", 0, nil, false
+	}
+	var stages []Entry
+	if match[3] != "" {
+		for _, stage := range strings.Split(match[3], ", ") {
+			name, durationStr, ok := strings.Cut(stage, ": ")
+			if !ok {
+				continue
+			}
+			stageDuration, err := time.ParseDuration(durationStr)
+			if err != nil {
+				continue
+			}
+			stages = append(stages, Entry{Name: name, Duration: stageDuration})
+		}
+	}
+	return match[1], duration, stages, true
+}
+
+func addStages(durations map[string]time.Duration, stages []Entry) {
+	for _, stage := range stages {
+		durations[stage.Name] += stage.Duration
+	}
+}
+
+// sortedEntries returns the entries of the provided map sorted by descending duration and then by name.
+func sortedEntries(durations map[string]time.Duration) []Entry {
+	entries := make([]Entry, 0, len(durations))
+	for name, duration := range durations {
+		entries = append(entries, Entry{Name: name, Duration: duration})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Duration != entries[j].Duration {
+			return entries[i].Duration > entries[j].Duration
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// formatDuration rounds the provided duration for display.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
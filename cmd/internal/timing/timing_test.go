@@ -0,0 +1,245 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testVerboseOutput = `level=info msg="[config_reader] Config search paths: [./ /project /]"
+level=info msg="[loader] Go packages loading at mode 8199 (compiled_files|files|name) took 1.5s"
+level=info msg="[linters_context/goanalysis] analyzers took 4s with top 10 stages: govet: 2.5s, errcheck: 1s, ineffassign: 500ms"
+level=info msg="[runner] processing took 49.051µs with stages: nolint_filter: 17.701µs, sort_results: 1.597µs"
+level=info msg="[runner] linters took 2s with stages: goanalysis_metalinter: 1.8s, gofmt: 200ms"
+level=warn msg="[runner] Can't run linter goanalysis_metalinter: some error"
+level=info msg="Memory: 2 samples, avg is 37.9MB, max is 37.9MB"
+level=info msg="Execution took 3.6s"
+0 issues.
+`
+
+func TestRecorderReport(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		outputs []string
+		want    Report
+	}{
+		{
+			name:    "verbose output of a single run",
+			outputs: []string{testVerboseOutput},
+			want: Report{
+				Total: 3600 * time.Millisecond,
+				Phases: []Entry{
+					{Name: "linters", Duration: 2 * time.Second},
+					{Name: "packages loading", Duration: 1500 * time.Millisecond},
+					{Name: "processing", Duration: 49051 * time.Nanosecond},
+				},
+				Linters: []Entry{
+					{Name: "govet", Duration: 2500 * time.Millisecond},
+					{Name: "errcheck", Duration: time.Second},
+					{Name: "ineffassign", Duration: 500 * time.Millisecond},
+					{Name: "gofmt", Duration: 200 * time.Millisecond},
+				},
+			},
+		},
+		{
+			name: "durations of multiple runs are summed",
+			outputs: []string{
+				`level=info msg="[runner] linters took 2s with stages: goanalysis_metalinter: 1.8s"` + "\n" + `level=info msg="Execution took 3s"` + "\n",
+				`level=info msg="[runner] linters took 1s with stages: goanalysis_metalinter: 900ms"` + "\n" + `level=info msg="Execution took 1s"`,
+			},
+			want: Report{
+				Total: 4 * time.Second,
+				Phases: []Entry{
+					{Name: "linters", Duration: 3 * time.Second},
+				},
+				Linters: []Entry{
+					{Name: "goanalysis_metalinter", Duration: 2700 * time.Millisecond},
+				},
+			},
+		},
+		{
+			name: "colored verbose output",
+			outputs: []string{"\x1b[36mINFO\x1b[0m [runner] linters took 2s with stages: goanalysis_metalinter: 1.8s, gofmt: 200ms \n" +
+				"\x1b[33mWARN\x1b[0m [runner] Can't run linter goanalysis_metalinter: some error\n" +
+				"\x1b[36mINFO\x1b[0m Execution took 3s                                 \n"},
+			want: Report{
+				Total: 3 * time.Second,
+				Phases: []Entry{
+					{Name: "linters", Duration: 2 * time.Second},
+				},
+				Linters: []Entry{
+					{Name: "goanalysis_metalinter", Duration: 1800 * time.Millisecond},
+					{Name: "gofmt", Duration: 200 * time.Millisecond},
+				},
+			},
+		},
+		{
+			name:    "output without timing",
+			outputs: []string{"0 issues.\n"},
+			want: Report{
+				Phases:  []Entry{},
+				Linters: []Entry{},
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			recorder := NewRecorder()
+			for _, output := range tc.outputs {
+				w := recorder.Writer(&bytes.Buffer{}, true)
+				_, err := w.Write([]byte(output))
+				require.NoError(t, err)
+				require.NoError(t, w.Flush())
+			}
+			assert.Equal(t, tc.want, recorder.Report())
+		})
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	for i, tc := range []struct {
+		name          string
+		filterVerbose bool
+		want          string
+	}{
+		{
+			name:          "verbose output is filtered",
+			filterVerbose: true,
+			want:          "level=warn msg=\"[runner] Can't run linter goanalysis_metalinter: some error\"\n0 issues.\n",
+		},
+		{
+			name: "verbose output is written",
+			want: testVerboseOutput,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewRecorder().Writer(&out, tc.filterVerbose)
+			// write the output in chunks that split lines
+			for remaining := testVerboseOutput; remaining != ""; {
+				n := min(7, len(remaining))
+				_, err := w.Write([]byte(remaining[:n]))
+				require.NoError(t, err)
+				remaining = remaining[n:]
+			}
+			require.NoError(t, w.Flush())
+			assert.Equal(t, tc.want, out.String())
+		})
+	}
+}
+
+func TestReportDominant(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		linters   []Entry
+		want      Entry
+		wantShare float64
+		wantOK    bool
+	}{
+		{
+			name: "dominant linter",
+			linters: []Entry{
+				{Name: "govet", Duration: 3 * time.Second},
+				{Name: "errcheck", Duration: time.Second},
+			},
+			want:      Entry{Name: "govet", Duration: 3 * time.Second},
+			wantShare: 0.75,
+			wantOK:    true,
+		},
+		{
+			name: "no dominant linter",
+			linters: []Entry{
+				{Name: "govet", Duration: time.Second},
+				{Name: "errcheck", Duration: time.Second},
+			},
+		},
+		{
+			name: "typecheck cannot be disabled",
+			linters: []Entry{
+				{Name: "typecheck", Duration: 3 * time.Second},
+				{Name: "errcheck", Duration: time.Second},
+			},
+		},
+		{
+			name: "single linter",
+			linters: []Entry{
+				{Name: "govet", Duration: time.Second},
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			got, gotShare, gotOK := Report{Linters: tc.linters}.Dominant()
+			assert.Equal(t, tc.want, got)
+			assert.InDelta(t, tc.wantShare, gotShare, 0.001)
+			assert.Equal(t, tc.wantOK, gotOK)
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	recorder := NewRecorder()
+	require.NoError(t, writeAll(recorder.Writer(&bytes.Buffer{}, true), testVerboseOutput))
+	var out bytes.Buffer
+	Print(&out, recorder.Report())
+	assert.Equal(t, strings.Join([]string{
+		"Timing:",
+		"  PHASE             DURATION",
+		"  linters           2s",
+		"  packages loading  1.5s",
+		"  processing        49µs",
+		"  total             3.6s",
+		"",
+		"  LINTER       DURATION  SHARE",
+		"  govet        2.5s      59.5%",
+		"  errcheck     1s        23.8%",
+		"  ineffassign  500ms     11.9%",
+		"  gofmt        200ms     4.8%",
+		"",
+		`govet accounts for 60% of the linter time: consider moving it to a profile in the "profiles" section of the plugin configuration, which only runs it when the profile is selected using "./godelw lint --profile <profile>".`,
+		"",
+	}, "\n"), out.String())
+}
+
+func TestWriteJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timing.json")
+	want := Report{
+		Total:   time.Second,
+		Phases:  []Entry{{Name: "linters", Duration: time.Second}},
+		Linters: []Entry{{Name: "govet", Duration: time.Millisecond}},
+	}
+	require.NoError(t, WriteJSONFile(path, want))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var got Report
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, want, got)
+	assert.Contains(t, string(data), `"duration": 1000000`)
+}
+
+func writeAll(w *LineWriter, output string) error {
+	if _, err := w.Write([]byte(output)); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolchain
+
+import (
+	"bytes"
+	"fmt"
+	"go/version"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+// ProjectGoVersions are the Go versions that determine the language version and standard library used when
+// golangci-lint loads the packages of a project.
+type ProjectGoVersions struct {
+	// path to the go.mod file of the project. Empty if the project does not have a go.mod file.
+	GoModPath string
+
+	// the version specified by the "go" directive of the go.mod file (for example, "go1.24.0"). Empty if the go.mod
+	// file does not exist or does not have a "go" directive.
+	GoDirective string
+
+	// the version specified by the "toolchain" directive of the go.mod file (for example, "go1.24.3"). Empty if the
+	// go.mod file does not exist or does not have a "toolchain" directive.
+	ToolchainDirective string
+
+	// the version reported by "go env GOVERSION" in the project directory (for example, "go1.24.3"). Empty if the go
+	// executable could not be run.
+	LocalGoVersion string
+}
+
+// ReadProjectGoVersions returns the ProjectGoVersions for the project in the provided directory. It is not an error
+// for the project to not have a go.mod file or for the go executable to be unavailable: in these cases, the
+// corresponding fields are empty. The provided environment variables (in the form "key=value") are added to the
+// environment of the go command.
+func ReadProjectGoVersions(projectDir string, env []string) (ProjectGoVersions, error) {
+	var versions ProjectGoVersions
+
+	goModPath := filepath.Join(projectDir, "go.mod")
+	goModBytes, err := os.ReadFile(goModPath)
+	if err != nil && !os.IsNotExist(err) {
+		return versions, errors.Wrapf(err, "failed to read %s", goModPath)
+	}
+	if err == nil {
+		goModFile, err := modfile.Parse(goModPath, goModBytes, nil)
+		if err != nil {
+			return versions, errors.Wrapf(err, "failed to parse %s", goModPath)
+		}
+		versions.GoModPath = goModPath
+		if goModFile.Go != nil {
+			versions.GoDirective = "go" + goModFile.Go.Version
+		}
+		if goModFile.Toolchain != nil {
+			versions.ToolchainDirective = goModFile.Toolchain.Name
+		}
+	}
+
+	cmd := exec.Command("go", "env", "GOVERSION")
+	cmd.Dir = projectDir
+	cmd.Env = append(os.Environ(), env...)
+	if output, err := cmd.Output(); err == nil {
+		versions.LocalGoVersion = string(bytes.TrimSpace(output))
+	}
+	return versions, nil
+}
+
+// VerifyCompatible returns an error if the golangci-lint executable at the provided path, which was built using the
+// Go version builtWith (for example, "go1.24.2"), cannot analyze the project with the provided versions.
+// golangci-lint type checks code using the go/types package it was built with, so it fails with type checking errors
+// when the project (or the standard library of the local Go toolchain) uses a newer Go language version than the one
+// golangci-lint was built with. Versions are compared at the language version level (for example, "go1.24"). Versions
+// that are empty or invalid are ignored.
+func VerifyCompatible(golangCILintPath, builtWith string, versions ProjectGoVersions) error {
+	if !version.IsValid(builtWith) {
+		return nil
+	}
+	builtWithLang := version.Lang(builtWith)
+
+	for _, projectVersion := range []struct {
+		version     string
+		description string
+		fix         string
+	}{
+		{
+			version:     versions.GoDirective,
+			description: fmt.Sprintf("the \"go\" directive in %s requires %s", versions.GoModPath, versions.GoDirective),
+			fix:         fmt.Sprintf("or lower the \"go\" directive to %s or earlier", builtWithLang),
+		},
+		{
+			version:     versions.ToolchainDirective,
+			description: fmt.Sprintf("the \"toolchain\" directive in %s selects %s", versions.GoModPath, versions.ToolchainDirective),
+			fix:         fmt.Sprintf("or lower the \"toolchain\" directive to %s or earlier", builtWithLang),
+		},
+		{
+			version:     versions.LocalGoVersion,
+			description: fmt.Sprintf("the local Go toolchain (\"go env GOVERSION\") is %s", versions.LocalGoVersion),
+			fix:         fmt.Sprintf("or run with a Go toolchain that is %s or earlier", builtWithLang),
+		},
+	} {
+		if !version.IsValid(projectVersion.version) {
+			continue
+		}
+		projectLang := version.Lang(projectVersion.version)
+		if version.Compare(projectLang, builtWithLang) <= 0 {
+			continue
+		}
+		return errors.Errorf("golangci-lint executable %s was built with %s, but %s: golangci-lint cannot analyze code for a newer Go version than the one it was built with. Use a golangci-lint asset built with %s or later, %s",
+			golangCILintPath, builtWith, projectVersion.description, projectLang, projectVersion.fix)
+	}
+	return nil
+}
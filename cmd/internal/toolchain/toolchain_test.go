@@ -0,0 +1,92 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolchain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProjectGoVersions(t *testing.T) {
+	projectDir := t.TempDir()
+	goModPath := filepath.Join(projectDir, "go.mod")
+	require.NoError(t, os.WriteFile(goModPath, []byte("module example.com/foo\n\ngo 1.24.0\n\ntoolchain go1.24.3\n"), 0644))
+
+	got, err := ReadProjectGoVersions(projectDir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, goModPath, got.GoModPath)
+	assert.Equal(t, "go1.24.0", got.GoDirective)
+	assert.Equal(t, "go1.24.3", got.ToolchainDirective)
+}
+
+func TestVerifyCompatible(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		builtWith string
+		versions  ProjectGoVersions
+		wantErr   string
+	}{
+		{
+			name:      "same language version with older patch is compatible",
+			builtWith: "go1.24.0",
+			versions: ProjectGoVersions{
+				GoModPath:          "/project/go.mod",
+				GoDirective:        "go1.24.0",
+				ToolchainDirective: "go1.24.3",
+				LocalGoVersion:     "go1.24.3",
+			},
+		},
+		{
+			name:      "newer go directive is incompatible",
+			builtWith: "go1.23.4",
+			versions: ProjectGoVersions{
+				GoModPath:   "/project/go.mod",
+				GoDirective: "go1.24.0",
+			},
+			wantErr: `golangci-lint executable /assets/golangci-lint was built with go1.23.4, but the "go" directive in /project/go.mod requires go1.24.0: golangci-lint cannot analyze code for a newer Go version than the one it was built with. Use a golangci-lint asset built with go1.24 or later, or lower the "go" directive to go1.23 or earlier`,
+		},
+		{
+			name:      "newer local Go toolchain is incompatible",
+			builtWith: "go1.24.2",
+			versions: ProjectGoVersions{
+				GoModPath:      "/project/go.mod",
+				GoDirective:    "go1.23.0",
+				LocalGoVersion: "go1.25.1",
+			},
+			wantErr: `golangci-lint executable /assets/golangci-lint was built with go1.24.2, but the local Go toolchain ("go env GOVERSION") is go1.25.1: golangci-lint cannot analyze code for a newer Go version than the one it was built with. Use a golangci-lint asset built with go1.25 or later, or run with a Go toolchain that is go1.24 or earlier`,
+		},
+		{
+			name:      "unknown build version is not verified",
+			builtWith: "unknown",
+			versions: ProjectGoVersions{
+				GoDirective: "go1.25.0",
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			err := VerifyCompatible("/assets/golangci-lint", tc.builtWith, tc.versions)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -15,10 +15,22 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/changes"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/fixpatch"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/modules"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/timing"
 	"github.com/palantir/godel-golangci-lint-plugin/config"
+	"github.com/palantir/godel-golangci-lint-plugin/report"
+	"github.com/palantir/godel-golangci-lint-plugin/runner"
 	godelconfig "github.com/palantir/godel/v2/framework/godel/config"
 	"github.com/palantir/pkg/matcher"
 	"github.com/pkg/errors"
@@ -26,7 +38,25 @@ import (
 )
 
 var (
-	fixFlagVal bool
+	fixFlagVal               bool
+	outputFlagVal            []string
+	baselineFlagVal          string
+	writeBaselineFlagVal     string
+	newFromRevFlagVal        string
+	newFromMergeBaseFlagVal  string
+	stagedFlagVal            bool
+	timeoutFlagVal           time.Duration
+	runTimeoutFlagVal        string
+	moduleConcurrencyFlagVal int
+	concurrencyFlagVal       int
+	memoryLimitFlagVal       string
+	gcPercentFlagVal         int
+	timingFlagVal            bool
+	timingJSONFlagVal        string
+	dryRunFlagVal            bool
+	patchOutFlagVal          string
+	reportFixableFlagVal     bool
+	profileFlagVal           []string
 
 	lintCmd = &cobra.Command{
 		Use:   "lint [flags] [checks]",
@@ -36,61 +66,637 @@ var (
 				"run",
 			}
 
-			// enable verbose logging if debug flag is set
-			if debugFlagVal {
+			// enable verbose logging if debug flag is set. The timing of the run is only logged in verbose mode, so it
+			// is also enabled if the timing is requested (the additional output is then not displayed).
+			timingRequested := timingFlagVal || timingJSONFlagVal != ""
+			if debugFlagVal || timingRequested {
 				preConfigArgs = append(preConfigArgs, "-v")
 			}
 
+			if len(profileFlagVal) > 0 && len(args) > 0 {
+				return errors.New("--profile cannot be specified with checks")
+			}
 			var postConfigArgs []string
 			if len(args) > 0 {
-				postConfigArgs = append([]string{
-					"--enable-only",
-				}, args...)
+				// the linters are provided as a single flag value so that they are not interpreted as packages
+				postConfigArgs = append(postConfigArgs, "--enable-only="+strings.Join(args, ","))
+			} else {
+				// the linters of profiles are only run when their profile is selected, unless they are specified
+				// explicitly
+				var profiles map[string]config.ProfileConfig
+				if loadedPluginConfig != nil {
+					profiles = loadedPluginConfig.Profiles
+				}
+				profileArgs, err := config.ProfileArgs(profiles, profileFlagVal)
+				if err != nil {
+					return err
+				}
+				postConfigArgs = append(postConfigArgs, profileArgs...)
 			}
 			if fixFlagVal {
 				postConfigArgs = append(postConfigArgs, "--fix")
 			}
+			if dryRunFlagVal && !fixFlagVal {
+				return errors.New("--dry-run can only be specified with --fix")
+			}
+			if patchOutFlagVal != "" && !dryRunFlagVal {
+				return errors.New("--patch-out can only be specified with --dry-run")
+			}
+			if dryRunFlagVal && stagedFlagVal {
+				return errors.New("--dry-run cannot be specified with --staged")
+			}
+			if reportFixableFlagVal && fixFlagVal {
+				return errors.New("--report-fixable cannot be specified with --fix")
+			}
+			runConfig, err := runConfigFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			var failOn []string
+			if loadedPluginConfig != nil {
+				failOn = loadedPluginConfig.Exit.FailOn
+			}
+			failPolicy, err := report.NewFailPolicy(failOn)
+			if err != nil {
+				return errors.Wrapf(err, "invalid exit.fail-on in plugin configuration")
+			}
+			postConfigArgs = append(postConfigArgs, runConfig.Args()...)
+			runTimeout, _ := runConfig.TimeoutDuration()
+			timeout := lintTimeout(timeoutFlagVal, runTimeout)
+			outputArgs, err := reportOutputArgs(outputFlagVal)
+			if err != nil {
+				return err
+			}
+			postConfigArgs = append(postConfigArgs, outputArgs...)
+
+			if baselineFlagVal != "" && writeBaselineFlagVal != "" {
+				return errors.New("--baseline and --write-baseline cannot both be specified")
+			}
+
+			if stagedFlagVal && (newFromRevFlagVal != "" || newFromMergeBaseFlagVal != "") {
+				return errors.New("--staged cannot be specified with --new-from-rev or --new-from-merge-base")
+			}
+			if newFromRevFlagVal != "" || newFromMergeBaseFlagVal != "" {
+				newFromArgs, pkgDirs, err := newFromArgsAndPackages(newFromRevFlagVal, newFromMergeBaseFlagVal, cmd.ErrOrStderr())
+				if err != nil {
+					return err
+				}
+				if pkgDirs != nil && len(pkgDirs) == 0 {
+					_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No packages are affected by the changes: nothing to lint")
+					return nil
+				}
+				postConfigArgs = append(postConfigArgs, newFromArgs...)
+				postConfigArgs = append(postConfigArgs, pkgDirs...)
+			}
+
+			env := runConfig.Env()
+			var timingRecorder *timing.Recorder
+			if timingRequested {
+				timingRecorder = timing.NewRecorder()
+				// the timing is only recorded from uncolored output
+				env = append(env, timing.PlainOutputEnv...)
+			}
 
-			return runDelegatedGolangCILintCommand(preConfigArgs, postConfigArgs, cmd.OutOrStdout(), cmd.ErrOrStderr(), debugFlagVal)
+			return runLintCommand(cmd.Context(), lintParams{
+				preConfigArgs:      preConfigArgs,
+				postConfigArgs:     postConfigArgs,
+				baselineFile:       projectPath(baselineFlagVal),
+				writeBaselineFile:  projectPath(writeBaselineFlagVal),
+				staged:             stagedFlagVal,
+				timeout:            timeout,
+				env:                env,
+				packagesRestricted: newFromRevFlagVal != "" || newFromMergeBaseFlagVal != "",
+				reportOutputs:      len(outputArgs) > 0 || (loadedPluginConfig != nil && len(loadedPluginConfig.Output.Formats) > 0),
+				moduleConcurrency:  moduleConcurrencyFlagVal,
+				timing:             timingRecorder,
+				timingJSONFile:     timingJSONFlagVal,
+				failPolicy:         failPolicy,
+				dryRun:             dryRunFlagVal,
+				patchOutFile:       patchOutFlagVal,
+				reportFixable:      reportFixableFlagVal,
+			}, cmd.OutOrStdout(), cmd.ErrOrStderr(), debugFlagVal)
 		},
 	}
 )
 
 // runDelegatedGolangCILintCommand runs the golangci-lint executable (asset) with the plugin configuration specified as
 // a flag (written to a temporary file and then referenced via flag) and the provided arguments provided before and
-// after the configuration flag. The provided stdout and stderr are used. This function returns an error only if it
-// fails to write/set up the configuration file: otherwise, it delegates to the asset runner and exits the process using
-// the same exit code as the asset runner process. As such, this function should be considered terminal: there should be
-// no expectation that this function returns control to the caller (including for running deferred functions or
-// cleanup).
-func runDelegatedGolangCILintCommand(preConfigArgs, postConfigArgs []string, stdout, stderr io.Writer, debugMode bool) error {
-	exitCode, err := assetRunner.RunGolangCILintWithConfig(preConfigArgs, postConfigArgs, stderr, stdout, debugMode)
+// after the configuration flag. The provided stdout and stderr are used. Full control is delegated to the golangci-lint
+// process: if it exits with a non-zero exit code, a summary of the outcome is printed and the returned error exits
+// this process with the same exit code once the temporary configuration file has been removed (see exitWithOutcome).
+// A shared lock on the golangci-lint cache is held while golangci-lint runs so that the cache is not cleaned while it
+// is in use.
+func runDelegatedGolangCILintCommand(ctx context.Context, preConfigArgs, postConfigArgs []string, stdout, stderr io.Writer, debugMode bool) error {
+	unlock, err := golangCILintCache.RLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	exitCode, err := assetRunner.RunGolangCILintWithConfig(ctx, preConfigArgs, postConfigArgs, stdout, stderr, debugMode)
 	if err != nil {
 		return err
 	}
+	return exitWithOutcome(report.ClassifyExitCode(exitCode), exitCode, stderr, report.FailPolicy{})
+}
+
+// lintParams are the parameters of a run of the lint command.
+type lintParams struct {
+	// arguments provided to golangci-lint before and after the configuration flag
+	preConfigArgs  []string
+	postConfigArgs []string
+
+	// if non-empty, only the issues that are not in this baseline file are reported
+	baselineFile string
+
+	// if non-empty, the issues are written to this baseline file
+	writeBaselineFile string
+
+	// if true, the content of the git index is linted rather than the content of the project directory
+	staged bool
+
+	// if positive, golangci-lint is stopped if it does not finish within this duration
+	timeout time.Duration
+
+	// environment variables added to the environment of golangci-lint, in the form "key=value"
+	env []string
+
+	// if true, only the packages specified in postConfigArgs are linted, so only the module in the project directory is
+	// linted even if the project contains other modules
+	packagesRestricted bool
+
+	// if true, golangci-lint writes report outputs, which is not supported when multiple modules are linted
+	reportOutputs bool
+
+	// the maximum number of modules that are linted concurrently
+	moduleConcurrency int
+
+	// if non-nil, the timing of the golangci-lint runs is recorded and printed (golangci-lint must be run in verbose
+	// mode)
+	timing *timing.Recorder
+
+	// if non-empty, the recorded timing is written as JSON to this file
+	timingJSONFile string
+
+	// determines which outcomes of the run fail the lint command
+	failPolicy report.FailPolicy
+
+	// if true, golangci-lint applies its fixes to a copy of the project directory and a patch of the fixes is written
+	// rather than the project directory being modified
+	dryRun bool
+
+	// if non-empty, the patch of a dry run is written to this file rather than to stdout
+	patchOutFile string
+
+	// if true, the issues that can be fixed automatically are printed first and the number of such issues is reported
+	reportFixable bool
+}
+
+// runLintCommand runs "golangci-lint run" in the same manner as runDelegatedGolangCILintCommand, but the issues are
+// written by golangci-lint as JSON to a side file and parsed into a report, and the plugin prints the issues (to
+// stdout) and the per-linter statistics (to stderr) itself. A summary of the outcome of the run is printed, and the
+// process exits using the exit code of golangci-lint (or the exit code determined by the baseline, if one is used) if
+// the outcome fails the run under the fail policy in the provided parameters. If the timeout in the provided parameters
+// is positive, golangci-lint is stopped if it does not finish within the timeout.
+func runLintCommand(ctx context.Context, params lintParams, stdout, stderr io.Writer, debugMode bool) error {
+	if params.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.timeout)
+		defer cancel()
+	}
+	unlock, err := golangCILintCache.RLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	exitCode, err := runLint(ctx, params, stdout, stderr, debugMode)
+	if err != nil {
+		return err
+	}
+	if exitCode == report.ExitCodeFailure {
+		// golangci-lint uses the same exit code for an invalid configuration and other failures, so the configuration
+		// is verified once the run failed to report an invalid configuration as such. The failure is reported as is if
+		// the configuration could not be verified (for example, because the JSON schema could not be downloaded).
+		verification, _, verifyOutput, err := verifyGolangCILintConfig(ctx, assetRunner.WithEnv(params.env), debugMode)
+		if err != nil {
+			return err
+		}
+		switch verification {
+		case configInvalid:
+			_, _ = io.WriteString(stderr, verifyOutput)
+			return exitWithOutcome(report.OutcomeConfigError, exitCode, stderr, params.failPolicy)
+		case configUnverified:
+			if debugMode {
+				_, _ = fmt.Fprintf(stderr, "Could not verify golangci-lint configuration:\n%s", verifyOutput)
+			}
+		}
+	}
+	return exitWithOutcome(report.ClassifyExitCode(exitCode), exitCode, stderr, params.failPolicy)
+}
+
+// runLint performs the work of runLintCommand and returns the exit code of the lint command.
+func runLint(ctx context.Context, params lintParams, stdout, stderr io.Writer, debugMode bool) (int, error) {
+	var baseline report.Baseline
+	if params.baselineFile != "" {
+		var err error
+		if baseline, err = report.ReadBaselineFile(params.baselineFile); err != nil {
+			return 0, err
+		}
+	}
+
+	lintRunner := assetRunner.WithEnv(params.env)
+	// the exit code of golangci-lint is classified and combined assuming that issues are reported using
+	// ExitCodeIssuesFound, which "run.issues-exit-code" in the configuration would otherwise change
+	postConfigArgs := append(params.postConfigArgs[:len(params.postConfigArgs):len(params.postConfigArgs)], report.IssuesExitCodeArg)
+	if params.baselineFile != "" || params.writeBaselineFile != "" || params.reportFixable {
+		// issues hidden by the golangci-lint limits would be missing from a baseline and reported as new once the
+		// issues that were reported are fixed, and would be missing from the number of auto-fixable issues
+		postConfigArgs = append(postConfigArgs[:len(postConfigArgs):len(postConfigArgs)], report.UncappedIssuesArgs...)
+	}
+	if params.staged {
+		overlayProjectDir, overlayDir, err := changes.MaterializeIndex(projectDir(), godelExcludes.Matcher(), []string{runner.OutDir})
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to materialize staged content")
+		}
+		defer func() {
+			// in debug mode, do not remove the staged content
+			if debugMode {
+				return
+			}
+			_ = os.RemoveAll(overlayDir)
+		}()
+
+		pkgDirs, all, err := changes.PackagesChangedInIndex(projectDir(), overlayProjectDir, goEnv)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to determine packages affected by staged changes")
+		}
+		if debugMode {
+			_, _ = fmt.Fprintf(stderr, "Linting staged content written to %s (affected packages: %v, all packages: %v)\n", overlayProjectDir, pkgDirs, all)
+		}
+		if !all {
+			if len(pkgDirs) == 0 {
+				_, _ = fmt.Fprintln(stderr, "No packages are affected by the staged changes: nothing to lint")
+				return 0, nil
+			}
+			postConfigArgs = append(postConfigArgs, pkgDirs...)
+		}
+		lintRunner = lintRunner.WithProjectDir(overlayProjectDir)
+	}
+
+	// in a dry run, the fixes are applied to a copy of the project directory. Unless the patch is written to a file, it
+	// is written to stdout, so the issues are written to stderr.
+	patchOut := stdout
+	var copyProjectDir string
+	if params.dryRun {
+		var (
+			copyDir string
+			err     error
+		)
+		// the godel output directory (which contains the configuration directory) is not needed to lint the project
+		copyProjectDir, copyDir, err = fixpatch.CopyProject(projectDir(), godelExcludes.Matcher(), []string{runner.OutDir})
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			// in debug mode, do not remove the copy
+			if debugMode {
+				return
+			}
+			_ = os.RemoveAll(copyDir)
+		}()
+		if debugMode {
+			_, _ = fmt.Fprintf(stderr, "Applying fixes to a copy of the project directory in %s\n", copyProjectDir)
+		}
+		lintRunner = lintRunner.WithProjectDir(copyProjectDir)
+		if params.patchOutFile == "" {
+			stdout = stderr
+		}
+	}
+
+	// if the project is a Go workspace, the members of the workspace are linted together; otherwise, each module in the
+	// project is linted separately. Only the module in the project directory is linted if only some of its packages are
+	// linted.
+	var projectModules []modules.Module
+	if !params.staged && !params.packagesRestricted {
+		memberPatterns, isWorkspace, err := workspaceMemberPatterns(stderr, debugMode)
+		if err != nil {
+			return 0, err
+		}
+		if isWorkspace {
+			if len(memberPatterns) == 0 {
+				_, _ = fmt.Fprintln(stderr, "All workspace members are excluded: nothing to lint")
+				return 0, nil
+			}
+			postConfigArgs = append(postConfigArgs, memberPatterns...)
+		} else if projectModules, err = modules.Discover(projectDir(), godelExcludes.Matcher()); err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		lintReport    *report.Report
+		exitCode      int
+		moduleResults []moduleResult
+		err           error
+	)
+	if len(projectModules) > 1 || (len(projectModules) == 1 && projectModules[0].Dir != ".") {
+		if params.reportOutputs {
+			return 0, errors.Errorf("report outputs are not supported when linting multiple modules: exclude the nested modules using the godel excludes to lint only the module in the project directory")
+		}
+		lintReport, exitCode, moduleResults, err = lintModules(ctx, lintRunner, projectModules, params.moduleConcurrency, params.preConfigArgs, postConfigArgs, stdout, stderr, params.timing, debugMode)
+	} else {
+		lintReport, exitCode, err = report.Run(func(outputArgs []string) (int, error) {
+			return runWithTiming(params.timing, stderr, debugMode, func(stderr io.Writer) (int, error) {
+				return lintRunner.RunGolangCILintWithConfig(ctx, params.preConfigArgs, append(postConfigArgs, outputArgs...), stdout, stderr, debugMode)
+			})
+		})
+	}
+	if err != nil {
+		return 0, err
+	}
+	if lintReport != nil {
+		switch {
+		case params.writeBaselineFile != "":
+			exitCode, err = writeBaseline(lintReport.Issues, params.writeBaselineFile, exitCode, stderr)
+			if err != nil {
+				return 0, err
+			}
+		case params.baselineFile != "":
+			exitCode = applyBaseline(lintReport.Issues, baseline, params.baselineFile, exitCode, params.reportFixable, stdout, stderr)
+		default:
+			printIssues(lintReport.Issues, params.reportFixable, stdout, stderr)
+		}
+	}
+	if moduleResults != nil {
+		printModuleSummary(stderr, moduleResults)
+	}
+	if params.dryRun {
+		if err := writeFixPatch(copyProjectDir, params.patchOutFile, patchOut, stderr); err != nil {
+			return 0, err
+		}
+	}
+	if params.timing != nil {
+		timingReport := params.timing.Report()
+		if params.timingJSONFile != "" {
+			if err := timing.WriteJSONFile(params.timingJSONFile, timingReport); err != nil {
+				return 0, err
+			}
+		}
+		timing.Print(stderr, timingReport)
+	}
+	return exitCode, nil
+}
 
-	// use os.Exit because full control is delegated to the golangci-lint process and exit code of this process should
-	// match the exit code of that process. Note that no deferred functions will run after this call.
-	os.Exit(exitCode)
+// writeFixPatch writes the patch of the fixes applied to the provided copy of the project directory to the provided
+// file, or to the provided stdout if the file is empty, and prints a summary of the patch to stderr.
+func writeFixPatch(copyProjectDir, patchOutFile string, stdout, stderr io.Writer) error {
+	if patchOutFile == "" {
+		changedFiles, err := fixpatch.WriteDiff(stdout, projectDir(), copyProjectDir)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(stderr, "Fixes would change %d file(s)\n", len(changedFiles))
+		return nil
+	}
 
+	var patch bytes.Buffer
+	changedFiles, err := fixpatch.WriteDiff(&patch, projectDir(), copyProjectDir)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(patchOutFile, patch.Bytes(), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write patch")
+	}
+	_, _ = fmt.Fprintf(stderr, "Wrote patch of fixes that change %d file(s) to %s\n", len(changedFiles), patchOutFile)
 	return nil
 }
 
-func getConfig(baseConfig []byte) (config.GolangCILintConfig, error) {
-	excludes, pluginConfig, err := projectParamFromFlags()
+// runWithTiming calls the provided function, which runs golangci-lint with the provided writer as its stderr. If the
+// provided recorder is non-nil, the timing in the output is recorded and, unless in debug mode, the verbose output
+// that was only enabled to record the timing is not written to stderr.
+func runWithTiming(recorder *timing.Recorder, stderr io.Writer, debugMode bool, run func(stderr io.Writer) (int, error)) (int, error) {
+	if recorder == nil {
+		return run(stderr)
+	}
+	timingWriter := recorder.Writer(stderr, !debugMode)
+	exitCode, err := run(timingWriter)
+	if flushErr := timingWriter.Flush(); err == nil {
+		err = flushErr
+	}
+	return exitCode, err
+}
+
+// workspaceMemberPatterns returns the package patterns that match the packages of the members of the Go workspace
+// defined by the go.work file in the project directory that should be linted (see Workspace.MembersToLint), and
+// whether the project directory is the root of a Go workspace. golangci-lint is run in the project directory, so all
+// members can be linted by a single run and the issues are reported relative to the root of the workspace.
+func workspaceMemberPatterns(stderr io.Writer, debugMode bool) ([]string, bool, error) {
+	if loadedPluginConfig != nil && loadedPluginConfig.Workspace.Ignore {
+		return nil, false, nil
+	}
+	workspace, err := modules.FindWorkspace(projectDir())
+	if err != nil {
+		return nil, false, err
+	}
+	if workspace == nil {
+		return nil, false, nil
+	}
+	resolvedProjectDir, err := filepath.EvalSymlinks(projectDir())
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read project excludes from flags")
+		return nil, false, errors.Wrapf(err, "failed to resolve project directory")
+	}
+	resolvedWorkspaceDir, err := filepath.EvalSymlinks(workspace.Dir())
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to resolve workspace directory")
+	}
+	if resolvedWorkspaceDir != resolvedProjectDir {
+		// the project is part of a workspace defined outside of the project, which golangci-lint uses when it loads the
+		// packages of the project
+		return nil, false, nil
+	}
+
+	var excludedMembers []string
+	if loadedPluginConfig != nil {
+		excludedMembers = loadedPluginConfig.Workspace.Exclude
+	}
+	members := workspace.MembersToLint(godelExcludes.Matcher(), excludedMembers)
+	if debugMode {
+		_, _ = fmt.Fprintf(stderr, "Linting members %v of the workspace defined by %s\n", members, workspace.File)
+	}
+	patterns := make([]string, 0, len(members))
+	for _, member := range members {
+		if member == "." {
+			patterns = append(patterns, "./...")
+			continue
+		}
+		patterns = append(patterns, "./"+member+"/...")
+	}
+	return patterns, true, nil
+}
+
+// timeoutBackstop is the time after the run timeout at which the plugin stops golangci-lint if it has not exited.
+const timeoutBackstop = 30 * time.Second
+
+// lintTimeout returns the duration after which the plugin stops golangci-lint given the timeout specified by the
+// "--timeout" flag, which the plugin enforces itself, and the run timeout of the plugin configuration, which is passed
+// to golangci-lint. golangci-lint stops itself when the run timeout is exceeded, so the plugin only stops golangci-lint
+// if it does not exit timeoutBackstop after the run timeout (for example, because it stopped responding). Returns 0 if
+// neither timeout is specified.
+func lintTimeout(flagTimeout, runTimeout time.Duration) time.Duration {
+	if runTimeout <= 0 {
+		return flagTimeout
+	}
+	if backstop := runTimeout + timeoutBackstop; flagTimeout <= 0 || backstop < flagTimeout {
+		return backstop
+	}
+	return flagTimeout
+}
+
+// runConfigFromFlags returns the run configuration of the plugin configuration, overridden by the environment
+// variables that are set (see config.ApplyRunConfigEnv) and then by the flags of the provided command that are set.
+// The run timeout is overridden by the "--run-timeout" flag rather than by the "--timeout" flag, which specifies a
+// timeout that the plugin enforces itself (see lintTimeout).
+func runConfigFromFlags(cmd *cobra.Command) (config.RunConfig, error) {
+	var runConfig config.RunConfig
+	if loadedPluginConfig != nil {
+		runConfig = loadedPluginConfig.Run
+	}
+	runConfig, err := config.ApplyRunConfigEnv(runConfig, os.Getenv)
+	if err != nil {
+		return config.RunConfig{}, err
+	}
+	if cmd.Flags().Changed("concurrency") {
+		runConfig.Concurrency = concurrencyFlagVal
+	}
+	if cmd.Flags().Changed("run-timeout") {
+		runConfig.Timeout = runTimeoutFlagVal
+	}
+	if cmd.Flags().Changed("memory-limit") {
+		runConfig.MemoryLimit = memoryLimitFlagVal
+	}
+	if cmd.Flags().Changed("gc-percent") {
+		runConfig.GCPercent = &gcPercentFlagVal
+	}
+	if err := runConfig.Validate(); err != nil {
+		return config.RunConfig{}, err
+	}
+	return runConfig, nil
+}
+
+// printIssues prints the provided issues to stdout and the per-linter statistics to stderr. If reportFixable is true,
+// the issues that can be fixed automatically are printed first and the number of such issues is printed to stderr.
+func printIssues(issues []report.Issue, reportFixable bool, stdout, stderr io.Writer) {
+	if !reportFixable {
+		report.PrintText(stdout, issues)
+		report.PrintStats(stderr, issues)
+		return
+	}
+	report.PrintTextFixableFirst(stdout, issues)
+	report.PrintStats(stderr, issues)
+	report.PrintFixableSummary(stderr, issues)
+}
+
+// writeBaseline writes the provided issues to the baseline file at the provided path and returns the exit code of the
+// lint command. The issues are recorded in the baseline, so the run succeeds even though issues were found.
+func writeBaseline(issues []report.Issue, baselineFile string, exitCode int, stderr io.Writer) (int, error) {
+	if err := report.WriteBaselineFile(baselineFile, report.NewBaseline(issues)); err != nil {
+		return 0, err
+	}
+	_, _ = fmt.Fprintf(stderr, "Wrote %d issue(s) to baseline %s\n", len(issues), baselineFile)
+	if exitCode == report.ExitCodeIssuesFound {
+		return 0, nil
+	}
+	return exitCode, nil
+}
+
+// applyBaseline prints the provided issues that are not in the provided baseline, along with a summary of the
+// baselined issues and of the baseline entries that have been fixed, and returns the exit code of the lint command,
+// which is determined by the new issues alone.
+func applyBaseline(issues []report.Issue, baseline report.Baseline, baselineFile string, exitCode int, reportFixable bool, stdout, stderr io.Writer) int {
+	result := baseline.Apply(issues)
+	printIssues(result.New, reportFixable, stdout, stderr)
+	if len(result.Baselined) > 0 {
+		_, _ = fmt.Fprintf(stderr, "%d issue(s) in baseline %s were not reported\n", len(result.Baselined), baselineFile)
+	}
+	if len(result.Fixed) > 0 {
+		_, _ = fmt.Fprintf(stderr, "%d issue(s) in baseline %s have been fixed (run with --write-baseline=%s to remove them from the baseline):\n", len(result.Fixed), baselineFile, baselineFile)
+		for _, entry := range result.Fixed {
+			_, _ = fmt.Fprintf(stderr, "  %s:%d: %s (%s)\n", entry.File, entry.Line, entry.Text, entry.Linter)
+		}
+	}
+	if len(result.New) == 0 && exitCode == report.ExitCodeIssuesFound {
+		return 0
+	}
+	return exitCode
+}
+
+// newFromArgsAndPackages returns the golangci-lint flags that report only the issues in the changes since the provided
+// revision (or since the merge base of HEAD and the provided branch) and the directories of the packages that are
+// affected by those changes (the packages in which files changed and their reverse dependencies). The returned
+// directories are nil if all packages should be linted (for example, because go.mod changed), and are empty but
+// non-nil if no packages are affected.
+func newFromArgsAndPackages(newFromRev, newFromMergeBase string, stderr io.Writer) ([]string, []string, error) {
+	if newFromRev != "" && newFromMergeBase != "" {
+		return nil, nil, errors.New("--new-from-rev and --new-from-merge-base cannot both be specified")
+	}
+
+	rev := newFromRev
+	newFromArgs := []string{"--new-from-rev=" + newFromRev}
+	if newFromMergeBase != "" {
+		mergeBase, err := changes.MergeBase(projectDir(), newFromMergeBase)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to determine merge base of HEAD and %s", newFromMergeBase)
+		}
+		rev = mergeBase
+		newFromArgs = []string{"--new-from-merge-base=" + newFromMergeBase}
+	}
+
+	pkgDirs, all, err := changes.PackagesChangedSince(projectDir(), rev, goEnv)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to determine packages affected by the changes since %s", rev)
+	}
+	if all {
+		if debugFlagVal {
+			_, _ = fmt.Fprintf(stderr, "Module configuration changed since %s: linting all packages\n", rev)
+		}
+		return newFromArgs, nil, nil
+	}
+	if debugFlagVal {
+		_, _ = fmt.Fprintf(stderr, "Packages affected by the changes since %s: %v\n", rev, pkgDirs)
+	}
+	if pkgDirs == nil {
+		pkgDirs = []string{}
+	}
+	return newFromArgs, pkgDirs, nil
+}
+
+// reportOutputArgs returns the golangci-lint flags that write the reports specified by the provided "--output" flag
+// values. Paths are resolved relative to the working directory, and take precedence over the report outputs in the
+// plugin configuration for the same format.
+func reportOutputArgs(outputs []string) ([]string, error) {
+	var args []string
+	for _, output := range outputs {
+		format, path, err := config.ParseReportOutput(output)
+		if err != nil {
+			return nil, err
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to determine absolute path of report output %s", path)
+		}
+		args = append(args, config.ReportOutputFlag(format), absPath)
 	}
-	return config.DefaultPalantirConfigMergedWithExcludeMatchersAndPluginConfig(baseConfig, excludes, pluginConfig)
+	return args, nil
 }
 
 func projectParamFromFlags() (matcher.NamesPathsCfg, *config.PluginConfig, error) {
-	godelExcludeConfig, err := godelconfig.ReadGodelConfigExcludesFromFile(godelConfigFileFlagVal)
+	godelExcludeConfig, err := godelconfig.ReadGodelConfigExcludesFromFile(godelConfigFile())
 	if err != nil {
 		return godelExcludeConfig, nil, err
 	}
 
-	pluginConfig, err := config.PluginConfigFromFile(pluginConfigFileFlagVal)
+	pluginConfig, err := config.PluginConfigFromFile(pluginConfigFile())
 	if err != nil {
 		// if plugin config does not exist, continue with nil config
 		if errors.Is(err, os.ErrNotExist) {
@@ -103,6 +709,24 @@ func projectParamFromFlags() (matcher.NamesPathsCfg, *config.PluginConfig, error
 
 func init() {
 	lintCmd.Flags().BoolVarP(&fixFlagVal, "fix", "", false, "Fix found issues (if it's supported by the linter)")
+	lintCmd.Flags().BoolVar(&dryRunFlagVal, "dry-run", false, "With --fix, apply the fixes to a temporary copy of the project and write a unified diff of the fixes to stdout (or to the file specified by --patch-out) rather than modifying the project. The issues are written to stderr when the diff is written to stdout")
+	lintCmd.Flags().StringVar(&patchOutFlagVal, "patch-out", "", "With --dry-run, write the unified diff of the fixes to the specified file rather than to stdout")
+	lintCmd.Flags().BoolVar(&reportFixableFlagVal, "report-fixable", false, "Print the issues that can be fixed automatically (using --fix) before the other issues and report the number of such issues. Cannot be specified with --fix")
+	lintCmd.Flags().StringVar(&newFromRevFlagVal, "new-from-rev", "", "Only report issues in the changes since the specified git revision and only lint the packages affected by those changes")
+	lintCmd.Flags().StringVar(&newFromMergeBaseFlagVal, "new-from-merge-base", "", "Only report issues in the changes since the merge base of HEAD and the specified branch and only lint the packages affected by those changes")
+	lintCmd.Flags().BoolVar(&stagedFlagVal, "staged", false, "Lint the content of the git index (the staged changes) rather than the content of the project directory, and only lint the packages affected by the staged changes")
+	lintCmd.Flags().StringVar(&baselineFlagVal, "baseline", "", "Only report issues that are not in the specified baseline file, relative to the project directory (the exit code is determined by these issues alone)")
+	lintCmd.Flags().StringVar(&writeBaselineFlagVal, "write-baseline", "", "Write the issues that are found to the specified baseline file, relative to the project directory")
+	lintCmd.Flags().IntVar(&moduleConcurrencyFlagVal, "module-concurrency", defaultModuleConcurrency, "Maximum number of modules that are linted concurrently when the project contains multiple modules")
+	lintCmd.Flags().DurationVar(&timeoutFlagVal, "timeout", 0, "Stop golangci-lint if it does not finish within the specified duration (for example, \"5m\") and exit with exit code 4. Unlike the golangci-lint timeout (see --run-timeout), the timeout is enforced by the plugin, so it also applies if golangci-lint hangs. 0 disables the timeout")
+	lintCmd.Flags().StringVar(&runTimeoutFlagVal, "run-timeout", "", "Duration after which golangci-lint stops linting (the golangci-lint --timeout flag, for example \"5m\"). Overrides the run timeout in the plugin configuration and the "+config.RunTimeoutEnvVar+" environment variable")
+	lintCmd.Flags().IntVar(&concurrencyFlagVal, "concurrency", 0, "Number of CPUs used by golangci-lint. Overrides the run concurrency in the plugin configuration and the "+config.RunConcurrencyEnvVar+" environment variable. 0 uses all available CPUs")
+	lintCmd.Flags().StringVar(&memoryLimitFlagVal, "memory-limit", "", "Soft memory limit of golangci-lint (GOMEMLIMIT, for example \"4GiB\"). Overrides the run memory limit in the plugin configuration and the "+config.RunMemoryLimitEnvVar+" environment variable")
+	lintCmd.Flags().IntVar(&gcPercentFlagVal, "gc-percent", 100, "Garbage collection target percentage of golangci-lint (GOGC). Overrides the run GC percent in the plugin configuration and the "+config.RunGCPercentEnvVar+" environment variable")
+	lintCmd.Flags().BoolVar(&timingFlagVal, "timing", false, "Print the time taken by each phase of the golangci-lint run and by each linter, sorted by duration")
+	lintCmd.Flags().StringVar(&timingJSONFlagVal, "timing-json", "", "Write the time taken by each phase of the golangci-lint run and by each linter as JSON to the specified file (implies --timing)")
+	lintCmd.Flags().StringArrayVar(&profileFlagVal, "profile", nil, "Also run the linters of the specified profile of the plugin configuration, which are not run otherwise (can be specified multiple times)")
+	lintCmd.Flags().StringArrayVar(&outputFlagVal, "output", nil, fmt.Sprintf("Write a report of the issues in the form <format>=<path> (can be specified multiple times). Supported formats: %s", strings.Join(config.ReportOutputFormats, ", ")))
 
 	rootCmd.AddCommand(lintCmd)
 }
@@ -0,0 +1,169 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/modules"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/timing"
+	"github.com/palantir/godel-golangci-lint-plugin/report"
+	"github.com/pkg/errors"
+)
+
+// defaultModuleConcurrency is the default maximum number of modules that are linted concurrently. Each golangci-lint
+// process is itself parallel, so running many at once mostly increases memory usage.
+const defaultModuleConcurrency = 2
+
+// moduleResult is the result of linting a single module.
+type moduleResult struct {
+	module   modules.Module
+	report   *report.Report
+	exitCode int
+	err      error
+}
+
+// lintModules runs "golangci-lint run" using the provided runner in each of the provided modules using at most the
+// provided number of concurrent golangci-lint processes and returns the report that combines the issues of all of the
+// modules (with paths relative to the project directory), the combined exit code (see combineExitCodes) and the result
+// for each module. The returned report is nil if no module produced a report. If the provided timing recorder is
+// non-nil, the timing of each golangci-lint process is recorded.
+func lintModules(ctx context.Context, lintRunner GolangCILintRunner, mods []modules.Module, concurrency int, preConfigArgs, postConfigArgs []string, stdout, stderr io.Writer, timingRecorder *timing.Recorder, debugMode bool) (*report.Report, int, []moduleResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	// the configuration of every module is created before any golangci-lint process is started so that a failure does
+	// not leave processes running
+	moduleRunners := make([]GolangCILintRunner, len(mods))
+	for i, module := range mods {
+		moduleCfg, err := moduleConfig(module.Dir)
+		if err != nil {
+			return nil, 0, nil, errors.Wrapf(err, "failed to create configuration for module %s", module.Dir)
+		}
+		moduleRunners[i] = lintRunner.WithModule(filepath.FromSlash(module.Dir), moduleCfg)
+	}
+
+	results := make([]moduleResult, len(mods))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, module := range mods {
+		results[i].module = module
+		moduleRunner := moduleRunners[i]
+
+		wg.Add(1)
+		go func(result *moduleResult) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() {
+				<-semaphore
+			}()
+			if debugMode {
+				_, _ = fmt.Fprintf(stderr, "Linting module %s in %s\n", result.module.Path, result.module.Dir)
+			}
+			result.report, result.exitCode, result.err = report.Run(func(outputArgs []string) (int, error) {
+				return runWithTiming(timingRecorder, stderr, debugMode, func(stderr io.Writer) (int, error) {
+					return moduleRunner.RunGolangCILintWithConfig(ctx, preConfigArgs, append(postConfigArgs[:len(postConfigArgs):len(postConfigArgs)], outputArgs...), stdout, stderr, debugMode)
+				})
+			})
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var (
+		combinedReport *report.Report
+		exitCodes      []int
+		runErrors      []string
+	)
+	for _, result := range results {
+		if result.err != nil {
+			return nil, 0, nil, errors.Wrapf(result.err, "failed to lint module %s", result.module.Dir)
+		}
+		exitCodes = append(exitCodes, result.exitCode)
+		if result.report == nil {
+			continue
+		}
+		if combinedReport == nil {
+			combinedReport = &report.Report{
+				Linters: result.report.Linters,
+			}
+		}
+		for _, issue := range result.report.Issues {
+			issue.Pos.Filename = filepath.Join(filepath.FromSlash(result.module.Dir), issue.Pos.Filename)
+			combinedReport.Issues = append(combinedReport.Issues, issue)
+		}
+		combinedReport.Warnings = append(combinedReport.Warnings, result.report.Warnings...)
+		if result.report.Error != "" {
+			runErrors = append(runErrors, fmt.Sprintf("%s: %s", result.module.Dir, result.report.Error))
+		}
+	}
+	if combinedReport != nil {
+		combinedReport.Error = strings.Join(runErrors, "\n")
+	}
+	return combinedReport, combineExitCodes(exitCodes), results, nil
+}
+
+// combineExitCodes returns the exit code of a lint run that consists of golangci-lint runs with the provided exit
+// codes. A module without Go files is skipped (its exit code only determines the combined exit code if no module has
+// Go files), so that it does not hide the result of the other modules. A failure (any other exit code than 0 and
+// ExitCodeIssuesFound) takes precedence over issues being found, and the first failure is reported if there are
+// several.
+func combineExitCodes(exitCodes []int) int {
+	combined := 0
+	noGoFilesExitCode, allNoGoFiles := 0, len(exitCodes) > 0
+	for _, exitCode := range exitCodes {
+		if report.ClassifyExitCode(exitCode) == report.OutcomeNoGoFiles {
+			noGoFilesExitCode = exitCode
+			continue
+		}
+		allNoGoFiles = false
+		switch {
+		case exitCode == 0:
+		case exitCode == report.ExitCodeIssuesFound:
+			if combined == 0 {
+				combined = exitCode
+			}
+		case combined == 0 || combined == report.ExitCodeIssuesFound:
+			combined = exitCode
+		}
+	}
+	if allNoGoFiles {
+		return noGoFilesExitCode
+	}
+	return combined
+}
+
+// printModuleSummary prints the outcome of linting each of the modules in the provided results.
+func printModuleSummary(w io.Writer, results []moduleResult) {
+	_, _ = fmt.Fprintf(w, "Linted %d modules:\n", len(results))
+	for _, result := range results {
+		var outcome string
+		switch {
+		case report.ClassifyExitCode(result.exitCode) == report.OutcomeNoGoFiles:
+			outcome = "skipped (no Go files)"
+		case result.exitCode != 0 && result.exitCode != report.ExitCodeIssuesFound:
+			outcome = fmt.Sprintf("failed with exit code %d", result.exitCode)
+		case result.report == nil || len(result.report.Issues) == 0:
+			outcome = "no issues"
+		default:
+			outcome = fmt.Sprintf("%d issue(s)", len(result.report.Issues))
+		}
+		_, _ = fmt.Fprintf(w, "  %s (%s): %s\n", result.module.Dir, result.module.Path, outcome)
+	}
+}
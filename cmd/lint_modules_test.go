@@ -0,0 +1,161 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/modules"
+	"github.com/palantir/godel-golangci-lint-plugin/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineExitCodes(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		exitCodes []int
+		want      int
+	}{
+		{
+			name: "no runs",
+			want: 0,
+		},
+		{
+			name:      "all runs succeed",
+			exitCodes: []int{0, 0},
+			want:      0,
+		},
+		{
+			name:      "issues found in one run",
+			exitCodes: []int{0, report.ExitCodeIssuesFound, 0},
+			want:      report.ExitCodeIssuesFound,
+		},
+		{
+			name:      "failure takes precedence over issues found before it",
+			exitCodes: []int{report.ExitCodeIssuesFound, 3},
+			want:      3,
+		},
+		{
+			name:      "failure takes precedence over issues found after it",
+			exitCodes: []int{4, report.ExitCodeIssuesFound},
+			want:      4,
+		},
+		{
+			name:      "first failure is reported",
+			exitCodes: []int{0, 4, report.ExitCodeIssuesFound, 3},
+			want:      4,
+		},
+		{
+			name:      "module without Go files does not hide issues found",
+			exitCodes: []int{5, report.ExitCodeIssuesFound},
+			want:      report.ExitCodeIssuesFound,
+		},
+		{
+			name:      "module without Go files does not hide success",
+			exitCodes: []int{0, 5},
+			want:      0,
+		},
+		{
+			name:      "no module has Go files",
+			exitCodes: []int{5, 5},
+			want:      5,
+		},
+	} {
+		assert.Equal(t, tc.want, combineExitCodes(tc.exitCodes), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestLintModules(t *testing.T) {
+	setUpFakeProject(t, nil)
+	mods := []modules.Module{
+		{Dir: ".", Path: "example.com/foo"},
+		{Dir: "bar", Path: "example.com/foo/bar"},
+		{Dir: "baz", Path: "example.com/foo/baz"},
+	}
+
+	for i, tc := range []struct {
+		name            string
+		moduleExitCodes map[string]int
+		wantExitCode    int
+		wantIssueFiles  []string
+	}{
+		{
+			name:           "issues of all modules are combined",
+			wantExitCode:   report.ExitCodeIssuesFound,
+			wantIssueFiles: []string{"foo.go", filepath.Join("bar", "foo.go"), filepath.Join("baz", "foo.go")},
+		},
+		{
+			name:            "module without Go files is skipped",
+			moduleExitCodes: map[string]int{"bar": 5},
+			wantExitCode:    report.ExitCodeIssuesFound,
+			wantIssueFiles:  []string{"foo.go", filepath.Join("baz", "foo.go")},
+		},
+		{
+			name:            "failure of a module takes precedence over issues",
+			moduleExitCodes: map[string]int{"bar": 3},
+			wantExitCode:    3,
+			wantIssueFiles:  []string{"foo.go", filepath.Join("baz", "foo.go")},
+		},
+	} {
+		runner := newFakeGolangCILintRunner(identicalIssues(1))
+		runner.moduleExitCodes = tc.moduleExitCodes
+
+		var stdout, stderr bytes.Buffer
+		lintReport, exitCode, results, err := lintModules(context.Background(), runner, mods, 2, []string{"run"}, nil, &stdout, &stderr, nil, false)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantExitCode, exitCode, "Case %d: %s", i, tc.name)
+		assert.Len(t, results, len(mods), "Case %d: %s", i, tc.name)
+		assert.Len(t, *runner.runs, len(mods), "Case %d: %s", i, tc.name)
+
+		require.NotNil(t, lintReport, "Case %d: %s", i, tc.name)
+		var issueFiles []string
+		for _, issue := range lintReport.Issues {
+			issueFiles = append(issueFiles, issue.Pos.Filename)
+		}
+		assert.Equal(t, tc.wantIssueFiles, issueFiles, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestPrintModuleSummary(t *testing.T) {
+	var buf bytes.Buffer
+	printModuleSummary(&buf, []moduleResult{
+		{
+			module:   modules.Module{Dir: ".", Path: "example.com/foo"},
+			report:   &report.Report{Issues: make([]report.Issue, 2)},
+			exitCode: report.ExitCodeIssuesFound,
+		},
+		{
+			module:   modules.Module{Dir: "bar", Path: "example.com/foo/bar"},
+			exitCode: 5,
+		},
+		{
+			module:   modules.Module{Dir: "baz", Path: "example.com/foo/baz"},
+			exitCode: 3,
+		},
+		{
+			module: modules.Module{Dir: "qux", Path: "example.com/foo/qux"},
+			report: &report.Report{},
+		},
+	})
+	assert.Equal(t, "Linted 4 modules:\n"+
+		"  . (example.com/foo): 2 issue(s)\n"+
+		"  bar (example.com/foo/bar): skipped (no Go files)\n"+
+		"  baz (example.com/foo/baz): failed with exit code 3\n"+
+		"  qux (example.com/foo/qux): no issues\n", buf.String())
+}
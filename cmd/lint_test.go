@@ -0,0 +1,333 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/lintcache"
+	"github.com/palantir/godel-golangci-lint-plugin/config"
+	"github.com/palantir/godel-golangci-lint-plugin/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGolangCILintRunner is a GolangCILintRunner that writes a JSON report of the provided issues in the same manner as
+// golangci-lint, including applying the default limit of 3 identical issues unless it is disabled.
+type fakeGolangCILintRunner struct {
+	issues []report.Issue
+
+	// if the module directory of the runner has an exit code, golangci-lint fails with that exit code without writing a
+	// report
+	moduleExitCodes map[string]int
+
+	// the exit code and output of "golangci-lint config verify", and the number of times it was run
+	configVerifyExitCode int
+	configVerifyOutput   string
+	configVerifyRuns     int
+
+	// the module directory set by WithModule
+	moduleDir string
+
+	// the module directory and the arguments provided after the configuration flag in each run. Shared by the copies
+	// of the runner.
+	runs *[]fakeRun
+	mu   *sync.Mutex
+}
+
+type fakeRun struct {
+	moduleDir      string
+	postConfigArgs []string
+}
+
+func newFakeGolangCILintRunner(issues []report.Issue) *fakeGolangCILintRunner {
+	return &fakeGolangCILintRunner{
+		issues: issues,
+		runs:   &[]fakeRun{},
+		mu:     &sync.Mutex{},
+	}
+}
+
+func (r *fakeGolangCILintRunner) Config() config.GolangCILintConfig {
+	return nil
+}
+
+func (r *fakeGolangCILintRunner) RunGolangCILint(ctx context.Context, args []string, stdout, stderr io.Writer, debugMode bool) int {
+	return 0
+}
+
+func (r *fakeGolangCILintRunner) RunGolangCILintWithConfig(ctx context.Context, preConfigArgs, postConfigArgs []string, stdout, stderr io.Writer, debugMode bool) (int, error) {
+	if slices.Equal(preConfigArgs, []string{"config", "verify"}) {
+		r.configVerifyRuns++
+		_, _ = io.WriteString(stderr, r.configVerifyOutput)
+		return r.configVerifyExitCode, nil
+	}
+
+	r.mu.Lock()
+	*r.runs = append(*r.runs, fakeRun{
+		moduleDir:      r.moduleDir,
+		postConfigArgs: postConfigArgs,
+	})
+	r.mu.Unlock()
+
+	if exitCode, ok := r.moduleExitCodes[r.moduleDir]; ok {
+		return exitCode, nil
+	}
+	issues := r.issues
+	if !slices.Contains(postConfigArgs, "--max-same-issues=0") && len(issues) > 3 {
+		issues = issues[:3]
+	}
+	jsonOutputPath := postConfigArgs[slices.Index(postConfigArgs, "--output.json.path")+1]
+	output, err := json.Marshal(map[string]any{
+		"Issues": issues,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(jsonOutputPath, output, 0644); err != nil {
+		return 0, err
+	}
+	if len(issues) > 0 {
+		return report.ExitCodeIssuesFound, nil
+	}
+	return 0, nil
+}
+
+func (r *fakeGolangCILintRunner) WithProjectDir(projectDir string) GolangCILintRunner {
+	return r
+}
+
+func (r *fakeGolangCILintRunner) WithModule(moduleDir string, moduleConfig config.GolangCILintConfig) GolangCILintRunner {
+	runner := *r
+	runner.moduleDir = moduleDir
+	return &runner
+}
+
+func (r *fakeGolangCILintRunner) WithEnv(env []string) GolangCILintRunner {
+	return r
+}
+
+// setUpFakeProject sets the project directory to a new directory that contains a single module, the runner used by
+// the lint command to the provided runner and the golangci-lint cache to a new directory for the duration of the test.
+func setUpFakeProject(t *testing.T, runner GolangCILintRunner) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/foo\n\ngo 1.24.0\n"), 0644))
+
+	origProjectDir, origRunner, origCache := projectDirFlagVal, assetRunner, golangCILintCache
+	t.Cleanup(func() {
+		projectDirFlagVal, assetRunner, golangCILintCache = origProjectDir, origRunner, origCache
+	})
+	projectDirFlagVal, assetRunner = dir, runner
+	golangCILintCache = lintcache.Cache{Dir: filepath.Join(t.TempDir(), "cache")}
+	return dir
+}
+
+func identicalIssues(n int) []report.Issue {
+	var issues []report.Issue
+	for i := 0; i < n; i++ {
+		issues = append(issues, report.Issue{
+			FromLinter:  "errcheck",
+			Text:        "Error return value is not checked",
+			SourceLines: []string{"\tos.Remove(path)"},
+			Pos: report.Position{
+				Filename: "foo.go",
+				Line:     10 * (i + 1),
+				Column:   2,
+			},
+		})
+	}
+	return issues
+}
+
+func TestRunLintBaselineIncludesAllIdenticalIssues(t *testing.T) {
+	runner := newFakeGolangCILintRunner(identicalIssues(5))
+	dir := setUpFakeProject(t, runner)
+	baselineFile := filepath.Join(dir, "baseline.json")
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := runLint(context.Background(), lintParams{
+		preConfigArgs:     []string{"run"},
+		writeBaselineFile: baselineFile,
+	}, &stdout, &stderr, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Contains(t, stderr.String(), "Wrote 5 issue(s) to baseline")
+
+	baseline, err := report.ReadBaselineFile(baselineFile)
+	require.NoError(t, err)
+	assert.Len(t, baseline.Issues, 5)
+
+	// applying the baseline reports no new issues even though more than 3 identical issues are found
+	stdout.Reset()
+	stderr.Reset()
+	exitCode, err = runLint(context.Background(), lintParams{
+		preConfigArgs: []string{"run"},
+		baselineFile:  baselineFile,
+	}, &stdout, &stderr, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "5 issue(s) in baseline")
+	assert.NotContains(t, stderr.String(), "have been fixed")
+
+	for _, run := range *runner.runs {
+		assert.Subset(t, run.postConfigArgs, report.UncappedIssuesArgs)
+	}
+}
+
+func TestRunLintCommandVerifiesConfigAfterFailure(t *testing.T) {
+	for i, tc := range []struct {
+		name                 string
+		exitCode             *int
+		configVerifyExitCode int
+		configVerifyOutput   string
+		wantConfigVerifyRuns int
+		wantExitCode         int
+		wantOutcome          report.Outcome
+	}{
+		{
+			name:         "configuration is not verified when issues are found",
+			wantExitCode: report.ExitCodeIssuesFound,
+			wantOutcome:  report.OutcomeIssuesFound,
+		},
+		{
+			name:                 "invalid configuration is reported after failure",
+			exitCode:             intPtr(report.ExitCodeFailure),
+			configVerifyExitCode: report.ExitCodeFailure,
+			configVerifyOutput:   "Failed to run: the configuration contains invalid elements\n",
+			wantConfigVerifyRuns: 1,
+			wantExitCode:         report.ExitCodeFailure,
+			wantOutcome:          report.OutcomeConfigError,
+		},
+		{
+			name:                 "failure is reported if configuration is valid",
+			exitCode:             intPtr(report.ExitCodeFailure),
+			wantConfigVerifyRuns: 1,
+			wantExitCode:         report.ExitCodeFailure,
+			wantOutcome:          report.OutcomeFailure,
+		},
+		{
+			name:                 "failure is reported if configuration cannot be verified",
+			exitCode:             intPtr(report.ExitCodeFailure),
+			configVerifyExitCode: report.ExitCodeFailure,
+			configVerifyOutput:   "Failed to run: failed to get JSON schema\n",
+			wantConfigVerifyRuns: 1,
+			wantExitCode:         report.ExitCodeFailure,
+			wantOutcome:          report.OutcomeFailure,
+		},
+	} {
+		runner := newFakeGolangCILintRunner(identicalIssues(1))
+		if tc.exitCode != nil {
+			runner.moduleExitCodes = map[string]int{"": *tc.exitCode}
+		}
+		runner.configVerifyExitCode = tc.configVerifyExitCode
+		runner.configVerifyOutput = tc.configVerifyOutput
+		setUpFakeProject(t, runner)
+
+		var stdout, stderr bytes.Buffer
+		err := runLintCommand(context.Background(), lintParams{
+			preConfigArgs: []string{"run"},
+		}, &stdout, &stderr, false)
+		assert.Equal(t, exitCodeError(tc.wantExitCode), err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantConfigVerifyRuns, runner.configVerifyRuns, "Case %d: %s", i, tc.name)
+		assert.Contains(t, stderr.String(), tc.wantOutcome.Summary(tc.wantExitCode), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestLintTimeout(t *testing.T) {
+	for i, tc := range []struct {
+		name        string
+		flagTimeout time.Duration
+		runTimeout  time.Duration
+		want        time.Duration
+	}{
+		{
+			name: "no timeout",
+			want: 0,
+		},
+		{
+			name:        "flag timeout is enforced by the plugin",
+			flagTimeout: time.Minute,
+			want:        time.Minute,
+		},
+		{
+			name:       "run timeout is enforced by the plugin after the backstop",
+			runTimeout: time.Minute,
+			want:       time.Minute + timeoutBackstop,
+		},
+		{
+			name:        "flag timeout shorter than run timeout",
+			flagTimeout: time.Minute,
+			runTimeout:  5 * time.Minute,
+			want:        time.Minute,
+		},
+		{
+			name:        "run timeout shorter than flag timeout",
+			flagTimeout: 5 * time.Minute,
+			runTimeout:  time.Minute,
+			want:        time.Minute + timeoutBackstop,
+		},
+	} {
+		assert.Equal(t, tc.want, lintTimeout(tc.flagTimeout, tc.runTimeout), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestRunLintReportFixableCountsAllIdenticalIssues(t *testing.T) {
+	issues := identicalIssues(5)
+	for i := range issues {
+		issues[i].SuggestedFixes = []report.SuggestedFix{{
+			TextEdits: []report.TextEdit{{Pos: 2, End: 2, NewText: []byte("_ = ")}},
+		}}
+	}
+	runner := newFakeGolangCILintRunner(issues)
+	setUpFakeProject(t, runner)
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := runLint(context.Background(), lintParams{
+		preConfigArgs: []string{"run"},
+		reportFixable: true,
+	}, &stdout, &stderr, false)
+	require.NoError(t, err)
+	assert.Equal(t, report.ExitCodeIssuesFound, exitCode)
+	assert.Contains(t, stderr.String(), "5 of 5 issues are auto-fixable")
+
+	for _, run := range *runner.runs {
+		assert.Subset(t, run.postConfigArgs, report.UncappedIssuesArgs)
+	}
+}
+
+func TestRunLintOverridesIssuesExitCode(t *testing.T) {
+	runner := newFakeGolangCILintRunner(identicalIssues(1))
+	setUpFakeProject(t, runner)
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := runLint(context.Background(), lintParams{
+		preConfigArgs: []string{"run"},
+	}, &stdout, &stderr, false)
+	require.NoError(t, err)
+	assert.Equal(t, report.ExitCodeIssuesFound, exitCode)
+
+	require.Len(t, *runner.runs, 1)
+	assert.Contains(t, (*runner.runs)[0].postConfigArgs, "--issues-exit-code=1")
+}
@@ -23,7 +23,7 @@ var (
 		Use:   "linters [flags]",
 		Short: "List current linters configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelegatedGolangCILintCommand([]string{"linters"}, nil, cmd.OutOrStdout(), cmd.ErrOrStderr(), debugFlagVal)
+			return runDelegatedGolangCILintCommand(cmd.Context(), []string{"linters"}, nil, cmd.OutOrStdout(), cmd.ErrOrStderr(), debugFlagVal)
 		},
 	}
 )
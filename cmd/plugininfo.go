@@ -39,6 +39,7 @@ var (
 			pluginapi.TaskInfoVerifyOptions(
 				pluginapi.VerifyOptionsOrdering(intPtr(verifyorder.Check)),
 				pluginapi.VerifyOptionsApplyTrueArgs("--fix"),
+				pluginapi.VerifyOptionsApplyFalseArgs("--report-fixable"),
 			),
 		),
 		pluginapi.PluginInfoTaskInfo(
@@ -46,6 +47,21 @@ var (
 			lintersCmd.Short,
 			pluginapi.TaskInfoCommand(lintersCmd.Name()),
 		),
+		pluginapi.PluginInfoTaskInfo(
+			doctorCmd.Name(),
+			doctorCmd.Short,
+			pluginapi.TaskInfoCommand(doctorCmd.Name()),
+		),
+		pluginapi.PluginInfoTaskInfo(
+			cacheCmd.Name(),
+			cacheCmd.Short,
+			pluginapi.TaskInfoCommand(cacheCmd.Name()),
+		),
+		pluginapi.PluginInfoTaskInfo(
+			installHookCmd.Name(),
+			installHookCmd.Short,
+			pluginapi.TaskInfoCommand(installHookCmd.Name()),
+		),
 	)
 )
 
@@ -15,9 +15,21 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/assetloader"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/embedded"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/gotool"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/lintcache"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/modules"
+	"github.com/palantir/godel-golangci-lint-plugin/cmd/internal/toolchain"
+	"github.com/palantir/godel-golangci-lint-plugin/config"
+	"github.com/palantir/godel-golangci-lint-plugin/runner"
 	"github.com/palantir/godel/v2/framework/pluginapi"
 	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/matcher"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -29,44 +41,342 @@ var (
 	pluginConfigFileFlagVal string
 	godelConfigFileFlagVal  string
 	assetsFlagVal           []string
+	golangCILintPathFlagVal string
 
 	// Package-level variable that is set by InitAssetCmds.
 	// Is guaranteed to be set and valid after InitAssetCmds is called (if it is not valid, an error is returned and
 	// the program will not run).
-	assetRunner *GolangCILintAssetRunner
+	assetRunner GolangCILintRunner
+
+	// Package-level variable that is set by InitAssetCmds. Records the config asset that provided each value of the
+	// configuration produced by merging the config assets.
+	configProvenance config.Provenance
+
+	// Package-level variable that is set by InitAssetCmds. Describes the golangci-lint executable and config assets
+	// used by the plugin.
+	loadedAssetInfo assetloader.AssetInfo
+
+	// Package-level variables that are set by InitAssetCmds. The inputs from which the configuration used by assetRunner
+	// was created, which are used to create the configuration for modules nested in the project directory (see
+	// moduleConfig).
+	baseGolangCILintConfig config.GolangCILintConfig
+	godelExcludes          matcher.NamesPathsCfg
+	loadedPluginConfig     *config.PluginConfig
+
+	// Package-level variables that are set by InitAssetCmds. The golangci-lint cache used by the plugin, and the
+	// directory that contains the caches for all golangci-lint executables (empty if the cache is specified by the
+	// GOLANGCI_LINT_CACHE environment variable).
+	golangCILintCache        lintcache.Cache
+	golangCILintCacheRootDir string
+
+	// Package-level variable that is set by InitAssetCmds. The environment variables (in the form "key=value") that are
+	// added to the environment of golangci-lint and of the go commands run by the plugin.
+	goEnv []string
+
+	// Package-level variable that is set by InitAssetCmds if initialization failed when running the doctor command,
+	// which reports the error rather than failing. If this variable is non-nil, assetRunner is not set.
+	assetInitErr error
 )
 
+// golangCILintPathEnvVar is the environment variable that can be used to specify the golangci-lint executable used by
+// the plugin. Takes precedence over the golangci-lint tool and asset, but not over the "--golangci-lint-path" flag.
+const golangCILintPathEnvVar = "GODEL_GOLANGCI_LINT_PATH"
+
 var rootCmd = &cobra.Command{
 	Use: "godel-golangci-lint-plugin",
 }
 
 func Execute() int {
-	return cobracli.ExecuteWithDebugVarAndDefaultParams(rootCmd, &debugFlagVal)
+	return cobracli.ExecuteWithDebugVarAndDefaultParams(rootCmd, &debugFlagVal, exitCodeExtractorParam)
 }
 
 func InitAssetCmds(args []string) error {
-	if _, _, err := rootCmd.Traverse(args); err != nil && err != pflag.ErrHelp {
+	traversedCmd, _, err := rootCmd.Traverse(args)
+	if err != nil && err != pflag.ErrHelp {
 		return errors.Wrapf(err, "failed to parse arguments")
 	}
 
-	assetInfo, err := assetloader.GetAssetInfo(assetsFlagVal)
+	if traversedCmd == installHookCmd {
+		// installing the hook does not require golangci-lint or its configuration
+		return nil
+	}
+
+	// the Go toolchain check runs "go env GOVERSION", so it is only performed by the commands that lint the project or
+	// diagnose problems with doing so rather than on every invocation of the plugin
+	if err := initAssetCmds(traversedCmd == lintCmd || traversedCmd == doctorCmd); err != nil {
+		if traversedCmd == doctorCmd {
+			// the doctor command reports the failure as a failed check
+			assetInitErr = err
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// initAssetCmds initializes the package-level variables used by the commands that run golangci-lint. If
+// verifyToolchain is true, it also verifies that golangci-lint can analyze code for the Go versions used by the project
+// (see toolchain.VerifyCompatible).
+func initAssetCmds(verifyToolchain bool) error {
+	excludes, pluginConfig, err := projectParamFromFlags()
+	if err != nil {
+		return errors.Wrap(err, "failed to read project configuration from flags")
+	}
+
+	goEnv = nil
+	if pluginConfig != nil && pluginConfig.Workspace.Ignore {
+		goEnv = []string{"GOWORK=off"}
+	}
+
+	var (
+		checksums assetloader.Checksums
+		backend   string
+	)
+	if pluginConfig != nil {
+		checksums = assetloader.Checksums{
+			GolangCILint: pluginConfig.Checksums.GolangCILint,
+			Config:       pluginConfig.Checksums.Config,
+		}
+		backend = pluginConfig.GolangCILintBackend
+	}
+	assetInfo, err := getAssetInfo(checksums, backend)
 	if err != nil {
 		return err
 	}
+	loadedAssetInfo = assetInfo
+	if debugFlagVal {
+		if assetInfo.GolangCILintSource.IsOverride() {
+			_, _ = fmt.Fprintf(rootCmd.ErrOrStderr(), "Using golangci-lint OVERRIDE %s at %s specified by the %s instead of the golangci-lint asset\n", assetInfo.GolangCILintVersion, assetInfo.GolangCILintAssetPath, assetInfo.GolangCILintSource)
+		} else {
+			_, _ = fmt.Fprintf(rootCmd.ErrOrStderr(), "Using golangci-lint %s at %s from %s\n", assetInfo.GolangCILintVersion, assetInfo.GolangCILintAssetPath, assetInfo.GolangCILintSource)
+		}
+		// the golangci-lint checksums are only declared for the golangci-lint asset
+		if len(checksums.GolangCILint) > 0 && assetInfo.GolangCILintSource != assetloader.GolangCILintSourceAsset {
+			_, _ = fmt.Fprintf(rootCmd.ErrOrStderr(), "Not verifying the golangci-lint checksum in the plugin configuration because golangci-lint is from %s rather than the golangci-lint asset\n", assetInfo.GolangCILintSource)
+		}
+	}
+
+	// each configuration asset may declare the golangci-lint versions that it supports
+	var (
+		versionConstraints []assetloader.VersionConstraint
+		configLayers       []config.ConfigLayer
+	)
+	for _, configAsset := range assetInfo.ConfigAssets {
+		assetVersionConstraint, assetConfig, err := config.ExtractGolangCILintVersionConstraint(configAsset.Content)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read golangci-lint version range from config asset %s", configAsset.Path)
+		}
+		versionConstraints = append(versionConstraints, assetloader.VersionConstraint{
+			Constraint: assetVersionConstraint,
+			Source:     fmt.Sprintf("the config asset %s", configAsset.Path),
+		})
+		configLayers = append(configLayers, config.ConfigLayer{
+			Name:   configAsset.Path,
+			Config: assetConfig,
+		})
+	}
+	if pluginConfig != nil {
+		versionConstraints = append(versionConstraints, assetloader.VersionConstraint{
+			Constraint: pluginConfig.GolangCILintVersion,
+			Source:     fmt.Sprintf("the plugin configuration (%s)", pluginConfigFile()),
+		})
+	}
+	if err := assetloader.VerifyGolangCILintVersion(assetInfo, versionConstraints...); err != nil {
+		return err
+	}
+
+	// golangci-lint must be built with a Go version that is at least as new as the one used by the project
+	if verifyToolchain {
+		projectGoVersions, err := toolchain.ReadProjectGoVersions(projectDir(), goEnv)
+		if err != nil {
+			return errors.Wrap(err, "failed to determine Go versions used by project")
+		}
+		if err := toolchain.VerifyCompatible(assetInfo.GolangCILintAssetPath, assetInfo.GolangCILintVersion.GoVersion, projectGoVersions); err != nil {
+			return err
+		}
+	}
+
+	// config assets are merged in order before the excludes and plugin configuration are applied
+	baseConfig, provenance, err := config.MergeConfigLayers(configLayers)
+	if err != nil {
+		return err
+	}
+	configProvenance = provenance
+	baseGolangCILintConfig = baseConfig
+	godelExcludes = excludes
+	loadedPluginConfig = pluginConfig
 
-	golangCILintConfig, err := getConfig(assetInfo.ConfigProvidedByAsset)
+	golangCILintConfig, err := config.DefaultPalantirConfigMergedWithExcludeMatchersAndPluginConfig(baseConfig, excludes, pluginConfig)
 	if err != nil {
 		return err
 	}
 
-	assetRunner = NewGolangCILintAssetRunner(assetInfo.GolangCILintAssetPath, golangCILintConfig)
+	if err := initGolangCILintCache(assetInfo, pluginConfig); err != nil {
+		return err
+	}
+
+	// golangci-lint is run in the project directory, so the path to the executable must not be relative to the working
+	// directory
+	golangCILintPath, err := filepath.Abs(assetInfo.GolangCILintAssetPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to determine absolute path of golangci-lint executable %s", assetInfo.GolangCILintAssetPath)
+	}
+	assetRunner = NewGolangCILintAssetRunner(golangCILintPath, assetInfo.GolangCILintSource, projectDir(), golangCILintConfig).WithEnv(goEnv)
+	if golangCILintCacheRootDir != "" {
+		// the cache is specific to the golangci-lint executable, so it is only provided to golangci-lint processes
+		// rather than set in the environment of the plugin
+		assetRunner = assetRunner.WithEnv([]string{lintcache.EnvVar + "=" + golangCILintCache.Dir})
+	}
 	return nil
 }
 
+// initGolangCILintCache sets the golangci-lint cache used by the plugin. If the GOLANGCI_LINT_CACHE environment
+// variable is set, it specifies the cache. Otherwise, the cache is a directory specific to the golangci-lint executable
+// (see lintcache.Key) in the directory specified by the plugin configuration, which is provided to golangci-lint using
+// the environment variable.
+func initGolangCILintCache(assetInfo assetloader.AssetInfo, pluginConfig *config.PluginConfig) error {
+	if cacheDir := os.Getenv(lintcache.EnvVar); cacheDir != "" {
+		golangCILintCache = lintcache.Cache{Dir: cacheDir}
+		return nil
+	}
+
+	var cacheConfig config.CacheConfig
+	if pluginConfig != nil {
+		cacheConfig = pluginConfig.Cache
+	}
+	switch {
+	case cacheConfig.Dir != "":
+		golangCILintCacheRootDir = projectPath(cacheConfig.Dir)
+	case cacheConfig.Scope == config.CacheScopeProject:
+		golangCILintCacheRootDir = filepath.Join(projectDir(), runner.ConfigDir, "cache")
+	case cacheConfig.Scope == "" || cacheConfig.Scope == config.CacheScopeUser:
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return errors.Wrapf(err, "failed to determine user cache directory for golangci-lint cache")
+		}
+		golangCILintCacheRootDir = filepath.Join(userCacheDir, "godel-golangci-lint-plugin", "golangci-lint-cache")
+	default:
+		return errors.Errorf("invalid cache scope %q in plugin configuration: must be %q or %q", cacheConfig.Scope, config.CacheScopeUser, config.CacheScopeProject)
+	}
+	absRootDir, err := filepath.Abs(golangCILintCacheRootDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to determine absolute path of golangci-lint cache directory %s", golangCILintCacheRootDir)
+	}
+	golangCILintCacheRootDir = absRootDir
+
+	key := lintcache.Key(assetInfo.GolangCILintVersion.Version, assetInfo.GolangCILintVersion.GoVersion, assetInfo.GolangCILintSHA256)
+	golangCILintCache = lintcache.New(golangCILintCacheRootDir, key)
+	if debugFlagVal {
+		_, _ = fmt.Fprintf(rootCmd.ErrOrStderr(), "Using golangci-lint cache %s\n", golangCILintCache.Dir)
+	}
+	return nil
+}
+
+// moduleConfig returns the golangci-lint configuration used to lint the module in the provided directory (relative to
+// the project directory). golangci-lint is run in the module directory, so the exclusions derived from the godel
+// excludes are made relative to the module directory.
+func moduleConfig(moduleDir string) (config.GolangCILintConfig, error) {
+	return config.DefaultPalantirConfigMergedWithExcludeMatchersAndPluginConfig(baseGolangCILintConfig, modules.RelativeExcludes(godelExcludes, moduleDir), loadedPluginConfig)
+}
+
+// getAssetInfo returns the AssetInfo for the assets provided to the plugin using the provided golangci-lint backend
+// (see config.PluginConfig.GolangCILintBackend). The golangci-lint executable is determined in the following order of
+// precedence:
+//
+//  1. The path specified by the "--golangci-lint-path" flag
+//  2. The path specified by the GODEL_GOLANGCI_LINT_PATH environment variable
+//  3. The golangci-lint embedded in the plugin, if the embedded backend is specified
+//  4. The golangci-lint tool declared in the project's go.mod file
+//  5. The golangci-lint asset
+//
+// The embedded golangci-lint is only used if the embedded backend is specified: if no golangci-lint asset is provided,
+// an error that describes how to select the embedded backend is returned.
+func getAssetInfo(checksums assetloader.Checksums, backend string) (assetloader.AssetInfo, error) {
+	switch backend {
+	case "", config.GolangCILintBackendExecutable, config.GolangCILintBackendEmbedded:
+	default:
+		return assetloader.AssetInfo{}, errors.Errorf("invalid golangci-lint backend %q in plugin configuration: must be %q or %q", backend, config.GolangCILintBackendExecutable, config.GolangCILintBackendEmbedded)
+	}
+
+	if golangCILintPathFlagVal != "" {
+		return assetloader.GetAssetInfoWithGolangCILint(assetsFlagVal, golangCILintPathFlagVal, assetloader.GolangCILintSourceOverrideFlag, checksums)
+	}
+	if golangCILintPath := os.Getenv(golangCILintPathEnvVar); golangCILintPath != "" {
+		return assetloader.GetAssetInfoWithGolangCILint(assetsFlagVal, golangCILintPath, assetloader.GolangCILintSourceOverrideEnvVar, checksums)
+	}
+	if backend == config.GolangCILintBackendEmbedded {
+		return getEmbeddedAssetInfo(checksums)
+	}
+
+	// if the project declares golangci-lint as a tool in its go.mod, use it instead of the golangci-lint asset
+	golangCILintToolPath, err := gotool.ResolveGolangCILintTool(projectDir(), goEnv)
+	if err != nil {
+		return assetloader.AssetInfo{}, err
+	}
+	if golangCILintToolPath != "" {
+		return assetloader.GetAssetInfoWithGolangCILint(assetsFlagVal, golangCILintToolPath, assetloader.GolangCILintSourceGoTool, checksums)
+	}
+	assetInfo, err := assetloader.GetAssetInfo(assetsFlagVal, checksums)
+	if err != nil && backend == "" && embedded.Available && assetloader.IsNoGolangCILintAssetError(err) {
+		return assetInfo, errors.Wrapf(err, "specify a golangci-lint asset or set \"golangci-lint-backend: %s\" in the plugin configuration to use the golangci-lint embedded in the plugin", config.GolangCILintBackendEmbedded)
+	}
+	return assetInfo, err
+}
+
+// getEmbeddedAssetInfo returns the AssetInfo for the golangci-lint embedded in the plugin and the config assets
+// provided to the plugin.
+func getEmbeddedAssetInfo(checksums assetloader.Checksums) (assetloader.AssetInfo, error) {
+	if !embedded.Available {
+		return assetloader.AssetInfo{}, errors.Errorf("golangci-lint backend %q is selected in the plugin configuration, but golangci-lint is not embedded in the plugin: use a plugin built with the %q build tag or specify a golangci-lint asset", config.GolangCILintBackendEmbedded, embedded.BuildTag)
+	}
+	pluginPath, err := os.Executable()
+	if err != nil {
+		return assetloader.AssetInfo{}, errors.Wrapf(err, "failed to determine path of plugin executable")
+	}
+	version := embedded.Version()
+	return assetloader.GetAssetInfoWithEmbeddedGolangCILint(assetsFlagVal, pluginPath, assetloader.VersionInfo{
+		Version:   version.Version,
+		Commit:    version.Commit,
+		Date:      version.Date,
+		GoVersion: version.GoVersion,
+	}, checksums)
+}
+
+// projectDir returns the project directory specified by the project directory flag, or the working directory if the
+// flag was not specified.
+func projectDir() string {
+	if projectDirFlagVal == "" {
+		return "."
+	}
+	return projectDirFlagVal
+}
+
+// projectPath returns the provided path resolved relative to the project directory. Returns the provided path if it is
+// empty or absolute.
+func projectPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(projectDir(), path)
+}
+
+// pluginConfigFile returns the path to the plugin configuration file specified by the config flag, resolved relative
+// to the project directory.
+func pluginConfigFile() string {
+	return projectPath(pluginConfigFileFlagVal)
+}
+
+// godelConfigFile returns the path to the godel configuration file specified by the godel config flag, resolved
+// relative to the project directory.
+func godelConfigFile() string {
+	return projectPath(godelConfigFileFlagVal)
+}
+
 func init() {
 	pluginapi.AddDebugPFlagPtr(rootCmd.PersistentFlags(), &debugFlagVal)
 	pluginapi.AddProjectDirPFlagPtr(rootCmd.PersistentFlags(), &projectDirFlagVal)
 	pluginapi.AddConfigPFlagPtr(rootCmd.PersistentFlags(), &pluginConfigFileFlagVal)
 	pluginapi.AddGodelConfigPFlagPtr(rootCmd.PersistentFlags(), &godelConfigFileFlagVal)
 	pluginapi.AddAssetsPFlagPtr(rootCmd.PersistentFlags(), &assetsFlagVal)
+	rootCmd.PersistentFlags().StringVar(&golangCILintPathFlagVal, "golangci-lint-path", "", "Path to a golangci-lint executable to use instead of the golangci-lint asset (for example, a locally built golangci-lint). Can also be specified using the "+golangCILintPathEnvVar+" environment variable")
 }
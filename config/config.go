@@ -52,9 +52,74 @@ func DefaultPalantirConfigMergedWithExcludeMatchersAndPluginConfig(baseConfig []
 		return nil, errors.Wrap(err, "failed to merge plugin config with default Palantir config")
 	}
 
+	mergedConfig, err = applyDefaultRelativePathMode(mergedConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set default relative path mode")
+	}
+
 	return mergedConfig, nil
 }
 
+// DefaultRelativePathMode is the value of "run.relative-path-mode" that is used if the configuration does not specify
+// one. The plugin runs golangci-lint in the project directory, so "wd" resolves the exclusion paths derived from the
+// godel excludes (which are relative to the project directory) correctly and reports issue paths relative to the
+// project directory. The golangci-lint default ("cfg") would resolve paths relative to the temporary directory that
+// contains the generated configuration file.
+const DefaultRelativePathMode = "wd"
+
+func applyDefaultRelativePathMode(configBytes GolangCILintConfig) (GolangCILintConfig, error) {
+	exists, err := checkNodeExists(configBytes, "/run/relative-path-mode")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check if relative path mode exists in config")
+	}
+	if exists {
+		return configBytes, nil
+	}
+	return applyAddOrSetYAMLMapPatch(configBytes, "/run", yaml.MapSlice{
+		{
+			Key:   "relative-path-mode",
+			Value: DefaultRelativePathMode,
+		},
+	})
+}
+
+// GolangCILintVersionKey is the top-level key that a configuration asset can use to declare a semantic version range
+// that the golangci-lint executable must satisfy. It is the same key used for this purpose in PluginConfig.
+const GolangCILintVersionKey = "golangci-lint-version"
+
+// ExtractGolangCILintVersionConstraint returns the value of the top-level GolangCILintVersionKey key in the provided
+// configuration along with a copy of the configuration with the key removed. The key is not a valid golangci-lint
+// configuration key, so it must be removed before the configuration is provided to golangci-lint. If the key does not
+// exist, returns an empty constraint and the provided configuration.
+func ExtractGolangCILintVersionConstraint(configBytes []byte) (string, []byte, error) {
+	yamlPath := "/" + GolangCILintVersionKey
+	if exists, err := checkNodeExists(configBytes, yamlPath); err != nil {
+		return "", nil, errors.Wrapf(err, "failed to check if %s exists in config", GolangCILintVersionKey)
+	} else if !exists {
+		return "", configBytes, nil
+	}
+
+	yPath, err := yaml.PathString(yamlPatchPathToGoccyPathString(yamlPath))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to parse yamlPath %q", yamlPath)
+	}
+	var constraint string
+	if err := yPath.Read(bytes.NewReader(configBytes), &constraint); err != nil {
+		return "", nil, errors.Wrapf(err, "failed to read %s from config as a string", GolangCILintVersionKey)
+	}
+
+	configWithoutConstraint, err := goccyyamlpatcher.New().Apply(configBytes, yamlpatch.Patch{
+		{
+			Type: yamlpatch.OperationRemove,
+			Path: yamlpatch.MustParsePath(yamlPath),
+		},
+	})
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to remove %s from config", GolangCILintVersionKey)
+	}
+	return constraint, configWithoutConstraint, nil
+}
+
 func convertNamesPathConfigsToPluginsConfig(namesPathsCfg matcher.NamesPathsCfg) *PluginConfig {
 	if len(namesPathsCfg.Names) == 0 && len(namesPathsCfg.Paths) == 0 {
 		return nil
@@ -68,6 +133,12 @@ func convertNamesPathConfigsToPluginsConfig(namesPathsCfg matcher.NamesPathsCfg)
 	}
 }
 
+// ExclusionsPathsForExcludeMatchers returns the "linters.exclusions.paths" entries that are added to the configuration
+// for the provided matchers by MergeExcludeMatchersWithConfig.
+func ExclusionsPathsForExcludeMatchers(matchers matcher.NamesPathsCfg) []string {
+	return convertNamesPathConfigsToExclusionsPaths(matchers)
+}
+
 func convertNamesPathConfigsToExclusionsPaths(namesPathsCfg matcher.NamesPathsCfg) []string {
 	if len(namesPathsCfg.Names) == 0 && len(namesPathsCfg.Paths) == 0 {
 		return nil
@@ -95,7 +166,10 @@ func convertNamesPathConfigsToExclusionsPaths(namesPathsCfg matcher.NamesPathsCf
 func MergePluginConfigWithConfig(configBytes GolangCILintConfig, cfg *PluginConfig) (GolangCILintConfig, error) {
 	// if "version" is not set, set it to version 2.
 	// This is explicitly required by golangci-lint per https://golangci-lint.run/docs/configuration/file/#version-configuration.
-	if exists, err := checkNodeExists(configBytes, "/version"); err != nil {
+	if len(bytes.TrimSpace(configBytes)) == 0 {
+		// an empty document has no root node to patch (for example, if no config asset was provided)
+		configBytes = GolangCILintConfig("version: \"2\"\n")
+	} else if exists, err := checkNodeExists(configBytes, "/version"); err != nil {
 		return nil, errors.Wrapf(err, "failed to check if version exists in config")
 	} else if !exists {
 		configBytes, err = goccyyamlpatcher.New().Apply(configBytes, yamlpatch.Patch{
@@ -147,6 +221,15 @@ func MergePluginConfigWithConfig(configBytes GolangCILintConfig, cfg *PluginConf
 		return nil, err
 	}
 
+	outputFormats, err := outputFormatsMapSlice(cfg.Output.Formats)
+	if err != nil {
+		return nil, err
+	}
+	applied, err = applyAddOrSetYAMLMapPatch(applied, "/output/formats", outputFormats)
+	if err != nil {
+		return nil, err
+	}
+
 	return applied, nil
 }
 
@@ -57,6 +57,30 @@ func Test_addPluginConfigToYAML(t *testing.T) {
 		pluginConfig string
 		want         string
 	}{
+		{
+			name: "adds output formats and replaces existing output formats",
+			baseConfig: `version: "2"
+output:
+  formats:
+    sarif:
+      path: base.sarif
+`,
+			pluginConfig: `output:
+  formats:
+    sarif:
+      path: out/lint/golangci-lint.sarif
+    checkstyle:
+      path: out/lint/checkstyle.xml
+`,
+			want: `version: "2"
+output:
+  formats:
+    sarif:
+      path: out/lint/golangci-lint.sarif
+    checkstyle:
+      path: out/lint/checkstyle.xml
+`,
+		},
 		{
 			name: "adds enable element to base config that has no linters element",
 			baseConfig: `version: "2"
@@ -70,6 +94,19 @@ func Test_addPluginConfigToYAML(t *testing.T) {
 linters:
   enable:
     - copyloopvar
+`,
+		},
+		{
+			name:       "adds enable element to empty base config",
+			baseConfig: ``,
+			pluginConfig: `linters:
+  enable:
+    - copyloopvar
+`,
+			want: `version: "2"
+linters:
+  enable:
+    - copyloopvar
 `,
 		},
 		{
@@ -705,3 +742,130 @@ func Test_applyAddOrSetYAMLMapPatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_applyDefaultRelativePathMode(t *testing.T) {
+	for i, tc := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "adds relative path mode if run section does not exist",
+			in: `version: "2"
+`,
+			want: `version: "2"
+run:
+  relative-path-mode: wd
+`,
+		},
+		{
+			name: "adds relative path mode to existing run section",
+			in: `version: "2"
+run:
+  timeout: 5m
+`,
+			want: `version: "2"
+run:
+  timeout: 5m
+  relative-path-mode: wd
+`,
+		},
+		{
+			name: "does not modify relative path mode specified by config",
+			in: `version: "2"
+run:
+  relative-path-mode: gomod
+`,
+			want: `version: "2"
+run:
+  relative-path-mode: gomod
+`,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			got, err := applyDefaultRelativePathMode(GolangCILintConfig(tc.in))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestParseReportOutput(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		value      string
+		wantFormat string
+		wantPath   string
+		wantErr    string
+	}{
+		{
+			name:       "valid report output",
+			value:      "junit-xml=out/lint/junit.xml",
+			wantFormat: "junit-xml",
+			wantPath:   "out/lint/junit.xml",
+		},
+		{
+			name:    "missing path",
+			value:   "sarif",
+			wantErr: `invalid report output "sarif": must be of the form <format>=<path>`,
+		},
+		{
+			name:    "unsupported format",
+			value:   "json=out.json",
+			wantErr: `unsupported report output format "json": must be one of [checkstyle code-climate html junit-xml sarif tab teamcity]`,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			gotFormat, gotPath, err := ParseReportOutput(tc.value)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantFormat, gotFormat)
+			assert.Equal(t, tc.wantPath, gotPath)
+		})
+	}
+}
+
+func TestExtractGolangCILintVersionConstraint(t *testing.T) {
+	for i, tc := range []struct {
+		name           string
+		in             string
+		wantConstraint string
+		wantConfig     string
+	}{
+		{
+			name: "config without constraint is unmodified",
+			in: `version: "2"
+linters:
+  default: none
+`,
+			wantConstraint: "",
+			wantConfig: `version: "2"
+linters:
+  default: none
+`,
+		},
+		{
+			name: "constraint is returned and removed from config",
+			in: `version: "2"
+golangci-lint-version: ">=2.1.0 <3"
+linters:
+  default: none
+`,
+			wantConstraint: ">=2.1.0 <3",
+			wantConfig: `version: "2"
+linters:
+  default: none
+`,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			gotConstraint, gotConfig, err := ExtractGolangCILintVersionConstraint([]byte(tc.in))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantConstraint, gotConstraint)
+			assert.Equal(t, tc.wantConfig, string(gotConfig))
+		})
+	}
+}
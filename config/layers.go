@@ -0,0 +1,165 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pkg/errors"
+)
+
+// ConfigLayer is a golangci-lint configuration that is merged on top of the layers that precede it.
+type ConfigLayer struct {
+	// Name identifies the layer in provenance information (for example, the path of the config asset).
+	Name string
+
+	// Config is the YAML golangci-lint configuration provided by the layer.
+	Config []byte
+}
+
+// Provenance records the name of the layer that provided each value of a merged configuration. The keys are the YAML
+// patch paths (for example, "/linters/enable/0") of the scalar values, sequence elements and empty collections in the
+// merged configuration.
+type Provenance map[string]string
+
+// Paths returns the paths in the provenance in sorted order.
+func (p Provenance) Paths() []string {
+	paths := make([]string, 0, len(p))
+	for path := range p {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// MergeConfigLayers merges the provided layers in order, where each layer is merged on top of the result of merging
+// the layers before it. Layers are merged in the following manner:
+//
+//   - Maps are merged recursively: keys that only exist in one of the maps are kept, and the values of keys that exist
+//     in both maps are merged
+//   - The elements of a sequence in a later layer are appended to the sequence at the same path in the result
+//   - Any other value (or a value whose type differs from the value at the same path in the result) in a later layer
+//     replaces the value at the same path in the result
+//
+// If there are no layers, the returned configuration is nil. If there is only a single layer, its configuration is
+// returned unmodified. The returned Provenance records the layer that provided each value in the merged
+// configuration.
+func MergeConfigLayers(layers []ConfigLayer) (GolangCILintConfig, Provenance, error) {
+	provenance := make(Provenance)
+	if len(layers) == 0 {
+		return nil, provenance, nil
+	}
+
+	var merged any
+	for idx, layer := range layers {
+		var layerValue any
+		if err := yaml.UnmarshalWithOptions(layer.Config, &layerValue, yaml.UseOrderedMap()); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to unmarshal config layer %s", layer.Name)
+		}
+		if idx == 0 {
+			merged = layerValue
+			provenance.record("", layerValue, layer.Name)
+			continue
+		}
+		merged = mergeLayerValue(merged, layerValue, "", layer.Name, provenance)
+	}
+
+	if len(layers) == 1 {
+		return layers[0].Config, provenance, nil
+	}
+	mergedBytes, err := yaml.MarshalWithOptions(merged, yaml.Indent(2), yaml.IndentSequence(true))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to marshal merged config layers")
+	}
+	return mergedBytes, provenance, nil
+}
+
+func mergeLayerValue(base, overlay any, path, layerName string, provenance Provenance) any {
+	if overlay == nil {
+		// a key with no value does not modify the base
+		return base
+	}
+
+	baseMap, baseIsMap := base.(yaml.MapSlice)
+	overlayMap, overlayIsMap := overlay.(yaml.MapSlice)
+	if baseIsMap && overlayIsMap {
+		merged := append(yaml.MapSlice(nil), baseMap...)
+	overlayItems:
+		for _, overlayItem := range overlayMap {
+			itemPath := path + "/" + fmt.Sprint(overlayItem.Key)
+			for idx := range merged {
+				if merged[idx].Key == overlayItem.Key {
+					merged[idx].Value = mergeLayerValue(merged[idx].Value, overlayItem.Value, itemPath, layerName, provenance)
+					continue overlayItems
+				}
+			}
+			merged = append(merged, overlayItem)
+			provenance.record(itemPath, overlayItem.Value, layerName)
+		}
+		return merged
+	}
+
+	baseSlice, baseIsSlice := base.([]any)
+	overlaySlice, overlayIsSlice := overlay.([]any)
+	if baseIsSlice && overlayIsSlice {
+		merged := append([]any(nil), baseSlice...)
+		for _, overlayElem := range overlaySlice {
+			provenance.record(fmt.Sprintf("%s/%d", path, len(merged)), overlayElem, layerName)
+			merged = append(merged, overlayElem)
+		}
+		return merged
+	}
+
+	provenance.remove(path)
+	provenance.record(path, overlay, layerName)
+	return overlay
+}
+
+// record records the provided layer as the provenance of every value in the provided value, which is located at the
+// provided path.
+func (p Provenance) record(path string, value any, layerName string) {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		if len(v) > 0 {
+			for _, item := range v {
+				p.record(path+"/"+fmt.Sprint(item.Key), item.Value, layerName)
+			}
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			for idx, elem := range v {
+				p.record(fmt.Sprintf("%s/%d", path, idx), elem, layerName)
+			}
+			return
+		}
+	}
+	if path == "" {
+		path = "/"
+	}
+	p[path] = layerName
+}
+
+// remove removes the provenance of the value at the provided path and of all the values nested within it.
+func (p Provenance) remove(path string) {
+	for currPath := range p {
+		if currPath == path || strings.HasPrefix(currPath, path+"/") {
+			delete(p, currPath)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConfigLayers(t *testing.T) {
+	companyLayer := ConfigLayer{
+		Name: "company.yml",
+		Config: []byte(`# company-wide configuration
+version: "2"
+linters:
+  default: none
+  enable:
+    - errcheck
+    - govet
+  settings:
+    errcheck:
+      check-type-assertions: true
+run:
+  relative-path-mode: gomod
+`),
+	}
+	teamLayer := ConfigLayer{
+		Name: "team.yml",
+		Config: []byte(`linters:
+  enable:
+    - gosec
+  settings:
+    errcheck:
+      check-blank: true
+    gosec:
+      excludes:
+        - G104
+run:
+  relative-path-mode: cfg
+`),
+	}
+
+	t.Run("no layers", func(t *testing.T) {
+		got, provenance, err := MergeConfigLayers(nil)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.Empty(t, provenance)
+	})
+
+	t.Run("single layer is returned unmodified", func(t *testing.T) {
+		got, provenance, err := MergeConfigLayers([]ConfigLayer{companyLayer})
+		require.NoError(t, err)
+		assert.Equal(t, string(companyLayer.Config), string(got))
+		assert.Equal(t, "company.yml", provenance["/linters/enable/1"])
+	})
+
+	t.Run("layers are merged in order", func(t *testing.T) {
+		got, provenance, err := MergeConfigLayers([]ConfigLayer{companyLayer, teamLayer})
+		require.NoError(t, err)
+		assert.Equal(t, `version: "2"
+linters:
+  default: none
+  enable:
+    - errcheck
+    - govet
+    - gosec
+  settings:
+    errcheck:
+      check-type-assertions: true
+      check-blank: true
+    gosec:
+      excludes:
+        - G104
+run:
+  relative-path-mode: cfg
+`, string(got))
+		assert.Equal(t, Provenance{
+			"/version":          "company.yml",
+			"/linters/default":  "company.yml",
+			"/linters/enable/0": "company.yml",
+			"/linters/enable/1": "company.yml",
+			"/linters/enable/2": "team.yml",
+			"/linters/settings/errcheck/check-type-assertions": "company.yml",
+			"/linters/settings/errcheck/check-blank":           "team.yml",
+			"/linters/settings/gosec/excludes/0":               "team.yml",
+			"/run/relative-path-mode":                          "team.yml",
+		}, provenance)
+	})
+}
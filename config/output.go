@@ -0,0 +1,91 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pkg/errors"
+)
+
+// ReportOutputFormats are the golangci-lint output formats that can be written as reports. The "text" and "json"
+// formats are not supported because the plugin uses them to print issues to the terminal.
+var ReportOutputFormats = []string{
+	"checkstyle",
+	"code-climate",
+	"html",
+	"junit-xml",
+	"sarif",
+	"tab",
+	"teamcity",
+}
+
+// VerifyReportOutputFormat returns an error if the provided format is not one of ReportOutputFormats.
+func VerifyReportOutputFormat(format string) error {
+	for _, supported := range ReportOutputFormats {
+		if format == supported {
+			return nil
+		}
+	}
+	return errors.Errorf("unsupported report output format %q: must be one of %v", format, ReportOutputFormats)
+}
+
+// ParseReportOutput parses a report output specified in the form "<format>=<path>" (for example,
+// "sarif=out/lint/golangci-lint.sarif").
+func ParseReportOutput(value string) (string, string, error) {
+	format, path, ok := strings.Cut(value, "=")
+	if !ok || path == "" {
+		return "", "", errors.Errorf("invalid report output %q: must be of the form <format>=<path>", value)
+	}
+	if err := VerifyReportOutputFormat(format); err != nil {
+		return "", "", err
+	}
+	return format, path, nil
+}
+
+// ReportOutputFlag returns the golangci-lint flag that sets the path of the report with the provided format.
+func ReportOutputFlag(format string) string {
+	return fmt.Sprintf("--output.%s.path", format)
+}
+
+// outputFormatsMapSlice returns the provided output formats as a map sorted by format so that the generated
+// configuration is deterministic.
+func outputFormatsMapSlice(formats map[string]OutputFormatConfig) (yaml.MapSlice, error) {
+	var sortedFormats []string
+	for format := range formats {
+		if err := VerifyReportOutputFormat(format); err != nil {
+			return nil, errors.Wrapf(err, "invalid output.formats in plugin config")
+		}
+		sortedFormats = append(sortedFormats, format)
+	}
+	sort.Strings(sortedFormats)
+
+	var mapSlice yaml.MapSlice
+	for _, format := range sortedFormats {
+		mapSlice = append(mapSlice, yaml.MapItem{
+			Key: format,
+			Value: yaml.MapSlice{
+				{
+					Key:   "path",
+					Value: formats[format].Path,
+				},
+			},
+		})
+	}
+	return mapSlice, nil
+}
@@ -27,7 +27,52 @@ import (
 // the configuration that can be specified by the user. This user-provided configuration
 // is merged with a hard-coded base configuration.
 type PluginConfig struct {
+	// GolangCILintVersion is a semantic version range (for example, ">=2.1.0 <3") that the version of the golangci-lint
+	// executable used by the plugin must satisfy. If empty, any version is allowed.
+	GolangCILintVersion string `yaml:"golangci-lint-version,omitempty"`
+
+	// GolangCILintBackend selects how golangci-lint is run: GolangCILintBackendExecutable runs a golangci-lint
+	// executable (the asset or a golangci-lint tool declared in go.mod), and GolangCILintBackendEmbedded runs the
+	// golangci-lint that is linked into the plugin (only if the plugin was built with the "golangci_lint_embedded" build
+	// tag). If empty, GolangCILintBackendExecutable is used: the embedded golangci-lint is only used if it is selected
+	// explicitly.
+	GolangCILintBackend string `yaml:"golangci-lint-backend,omitempty"`
+
+	// Checksums are the expected SHA-256 checksums of the assets provided to the plugin. The plugin refuses to run if
+	// an asset does not match its declared checksum.
+	Checksums ChecksumsConfig `yaml:"checksums,omitempty"`
+
 	Linters LintersConfig `yaml:"linters,omitempty"`
+
+	// Output configures the reports that golangci-lint writes when the plugin lints the project.
+	Output OutputConfig `yaml:"output,omitempty"`
+
+	// Workspace configures how the plugin lints a project that contains a go.work file.
+	Workspace WorkspaceConfig `yaml:"workspace,omitempty"`
+
+	// Cache configures the location of the golangci-lint cache.
+	Cache CacheConfig `yaml:"cache,omitempty"`
+
+	// Run controls the resources used by golangci-lint when the plugin lints the project.
+	Run RunConfig `yaml:"run,omitempty"`
+
+	// Profiles are sets of linters that are only run when they are selected using the "--profile" flag of the lint
+	// task, keyed by profile name.
+	Profiles map[string]ProfileConfig `yaml:"profiles,omitempty"`
+
+	// Exit determines which outcomes of a golangci-lint run fail the lint task.
+	Exit ExitConfig `yaml:"exit,omitempty"`
+}
+
+// ChecksumsConfig specifies the expected SHA-256 checksums of the assets provided to the plugin. Checksums are keyed by
+// OS/architecture (for example, "linux-amd64") in the same manner as the "checksums" of a godel plugin locator.
+type ChecksumsConfig struct {
+	// GolangCILint are the checksums of the golangci-lint asset.
+	GolangCILint map[string]string `yaml:"golangci-lint,omitempty"`
+
+	// Config are the checksums of the config assets. Must contain one element for each config asset, in the order in
+	// which the config assets are provided.
+	Config []map[string]string `yaml:"config,omitempty"`
 }
 
 type LintersConfig struct {
@@ -51,6 +96,56 @@ type RulesConfig struct {
 	Source     string   `yaml:"source,omitempty"`
 }
 
+// OutputConfig mirrors the "output" section of the golangci-lint configuration.
+type OutputConfig struct {
+	// Formats are the reports written in addition to the issues that the plugin prints to the terminal, keyed by
+	// golangci-lint output format (see ReportOutputFormats). Paths are relative to the project directory.
+	Formats map[string]OutputFormatConfig `yaml:"formats,omitempty"`
+}
+
+type OutputFormatConfig struct {
+	Path string `yaml:"path,omitempty"`
+}
+
+// WorkspaceConfig configures how the plugin lints a project whose directory contains a go.work file.
+type WorkspaceConfig struct {
+	// Exclude are the directories of workspace members (as specified in the go.work file) that are not linted.
+	Exclude []string `yaml:"exclude,omitempty"`
+
+	// Ignore specifies that the go.work file should be ignored: golangci-lint is run with GOWORK=off, and each module
+	// in the project is linted separately.
+	Ignore bool `yaml:"ignore,omitempty"`
+}
+
+// CacheConfig configures the directory under which the plugin stores the golangci-lint cache. A separate cache is
+// stored in this directory for each golangci-lint executable.
+type CacheConfig struct {
+	// Scope is either CacheScopeUser (the default), in which case the cache is stored in the user cache directory and
+	// shared by all projects, or CacheScopeProject, in which case the cache is stored in the project directory.
+	Scope string `yaml:"scope,omitempty"`
+
+	// Dir is the directory under which the cache is stored (relative to the project directory). Takes precedence over
+	// Scope if specified.
+	Dir string `yaml:"dir,omitempty"`
+}
+
+// ExitConfig determines which outcomes of a golangci-lint run (such as "issues-found" or "config-error") fail the lint
+// task, and therefore the verify task. Outcomes that do not fail the task are still reported.
+type ExitConfig struct {
+	// FailOn are the outcomes that fail the lint task (see report.FailOnOutcomes). If empty, all of them fail the task.
+	FailOn []string `yaml:"fail-on,omitempty"`
+}
+
+const (
+	CacheScopeUser    = "user"
+	CacheScopeProject = "project"
+)
+
+const (
+	GolangCILintBackendExecutable = "executable"
+	GolangCILintBackendEmbedded   = "embedded"
+)
+
 func PluginConfigFromFile(configFile string) (*PluginConfig, error) {
 	configBytes, err := os.ReadFile(configFile)
 	if err != nil {
@@ -59,6 +154,18 @@ func PluginConfigFromFile(configFile string) (*PluginConfig, error) {
 	return PluginConfigFromBytes(configBytes)
 }
 
+// PluginConfigFromBytesStrict unmarshals the provided plugin configuration in the same manner as
+// PluginConfigFromBytes, except that it returns an error if the configuration contains keys that are not part of the
+// plugin configuration. Such keys are ignored by PluginConfigFromBytes, so this can be used to detect typos and keys
+// that are not supported by the plugin.
+func PluginConfigFromBytesStrict(configBytes []byte) (*PluginConfig, error) {
+	var cfg PluginConfig
+	if err := yaml.UnmarshalWithOptions(configBytes, &cfg, yaml.Strict()); err != nil {
+		return nil, errors.Wrapf(err, "failed to strictly unmarshal golangci-lint plugin config")
+	}
+	return &cfg, nil
+}
+
 func PluginConfigFromBytes(configBytes []byte) (*PluginConfig, error) {
 	var cfg PluginConfig
 	if err := yaml.Unmarshal(configBytes, &cfg); err != nil {
@@ -0,0 +1,80 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ProfileConfig is a set of linters that the plugin only runs when the profile is selected using the "--profile" flag
+// of the lint task, such as slow linters that are run less often than the other linters (for example, in a separate CI
+// job). Like RunConfig, it is not merged into the golangci-lint configuration: it is translated into golangci-lint
+// flags (see ProfileArgs).
+type ProfileConfig struct {
+	// Linters are the linters of the profile, which are disabled unless the profile is selected.
+	Linters []string `yaml:"linters,omitempty"`
+}
+
+// ProfileArgs returns the golangci-lint "run" flags that disable the linters of the provided profiles that are not
+// selected and enable the linters of the selected profiles. A linter that is in both a selected profile and a profile
+// that is not selected is enabled. Returns an error if a selected profile does not exist.
+func ProfileArgs(profiles map[string]ProfileConfig, selected []string) ([]string, error) {
+	var enable []string
+	enabled := make(map[string]bool)
+	for _, name := range selected {
+		profile, ok := profiles[name]
+		if !ok {
+			return nil, errors.Errorf("profile %q is not defined in the plugin configuration: must be one of %v", name, sortedProfileNames(profiles))
+		}
+		for _, linter := range profile.Linters {
+			if !enabled[linter] {
+				enabled[linter] = true
+				enable = append(enable, linter)
+			}
+		}
+	}
+
+	var disable []string
+	disabled := make(map[string]bool)
+	for _, name := range sortedProfileNames(profiles) {
+		for _, linter := range profiles[name].Linters {
+			if !enabled[linter] && !disabled[linter] {
+				disabled[linter] = true
+				disable = append(disable, linter)
+			}
+		}
+	}
+
+	var args []string
+	if len(disable) > 0 {
+		args = append(args, "--disable="+strings.Join(disable, ","))
+	}
+	if len(enable) > 0 {
+		args = append(args, "--enable="+strings.Join(enable, ","))
+	}
+	return args, nil
+}
+
+func sortedProfileNames(profiles map[string]ProfileConfig) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileArgs(t *testing.T) {
+	profiles := map[string]ProfileConfig{
+		"slow": {
+			Linters: []string{"gosec", "dupl"},
+		},
+		"security": {
+			Linters: []string{"gosec", "bodyclose"},
+		},
+	}
+	for i, tc := range []struct {
+		name     string
+		profiles map[string]ProfileConfig
+		selected []string
+		wantArgs []string
+		wantErr  string
+	}{
+		{
+			name: "no profiles",
+		},
+		{
+			name:     "linters of profiles are disabled if no profile is selected",
+			profiles: profiles,
+			wantArgs: []string{"--disable=gosec,bodyclose,dupl"},
+		},
+		{
+			name:     "linters of selected profile are enabled",
+			profiles: profiles,
+			selected: []string{"slow"},
+			wantArgs: []string{"--disable=bodyclose", "--enable=gosec,dupl"},
+		},
+		{
+			name:     "linters of all selected profiles are enabled",
+			profiles: profiles,
+			selected: []string{"slow", "security"},
+			wantArgs: []string{"--enable=gosec,dupl,bodyclose"},
+		},
+		{
+			name:     "unknown profile",
+			profiles: profiles,
+			selected: []string{"fast"},
+			wantErr:  `profile "fast" is not defined in the plugin configuration: must be one of [security slow]`,
+		},
+	} {
+		args, err := ProfileArgs(tc.profiles, tc.selected)
+		if tc.wantErr != "" {
+			require.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantArgs, args, "Case %d: %s", i, tc.name)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RunConfig controls the resources used by golangci-lint when the plugin runs it. Unlike the other sections of the
+// plugin configuration, it is not merged into the golangci-lint configuration: it is translated into golangci-lint
+// flags (see Args) and into environment variables of the golangci-lint process (see Env).
+type RunConfig struct {
+	// Concurrency is the number of CPUs that golangci-lint uses (the "--concurrency" flag). If 0, golangci-lint uses
+	// all available CPUs.
+	Concurrency int `yaml:"concurrency,omitempty"`
+
+	// Timeout is the duration (for example, "5m") after which golangci-lint stops linting (the "--timeout" flag). If
+	// empty, there is no timeout.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// MemoryLimit is the soft memory limit of the golangci-lint process (GOMEMLIMIT), which is a number of bytes with
+	// an optional unit suffix (for example, "4GiB").
+	MemoryLimit string `yaml:"memory-limit,omitempty"`
+
+	// GCPercent is the garbage collection target percentage of the golangci-lint process (GOGC). Lower values reduce
+	// memory usage at the cost of CPU time.
+	GCPercent *int `yaml:"gc-percent,omitempty"`
+}
+
+// Environment variables that override the values in RunConfig (see ApplyRunConfigEnv), which allow CI systems to
+// control the resources used by golangci-lint without changing the plugin configuration.
+const (
+	RunConcurrencyEnvVar = "GODEL_GOLANGCI_LINT_CONCURRENCY"
+	RunTimeoutEnvVar     = "GODEL_GOLANGCI_LINT_TIMEOUT"
+	RunMemoryLimitEnvVar = "GODEL_GOLANGCI_LINT_MEMORY_LIMIT"
+	RunGCPercentEnvVar   = "GODEL_GOLANGCI_LINT_GC_PERCENT"
+)
+
+// memoryLimitRegexp matches the values of GOMEMLIMIT that the Go runtime accepts.
+var memoryLimitRegexp = regexp.MustCompile(`^(off|[0-9]+(B|KiB|MiB|GiB|TiB)?)$`)
+
+// ApplyRunConfigEnv returns the provided configuration with the values overridden by the environment variables (as
+// returned by the provided function) that are set.
+func ApplyRunConfigEnv(cfg RunConfig, getenv func(string) string) (RunConfig, error) {
+	if val := getenv(RunConcurrencyEnvVar); val != "" {
+		concurrency, err := strconv.Atoi(val)
+		if err != nil {
+			return RunConfig{}, errors.Wrapf(err, "invalid value of %s", RunConcurrencyEnvVar)
+		}
+		cfg.Concurrency = concurrency
+	}
+	if val := getenv(RunTimeoutEnvVar); val != "" {
+		cfg.Timeout = val
+	}
+	if val := getenv(RunMemoryLimitEnvVar); val != "" {
+		cfg.MemoryLimit = val
+	}
+	if val := getenv(RunGCPercentEnvVar); val != "" {
+		gcPercent, err := strconv.Atoi(val)
+		if err != nil {
+			return RunConfig{}, errors.Wrapf(err, "invalid value of %s", RunGCPercentEnvVar)
+		}
+		cfg.GCPercent = &gcPercent
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if any of the values of the configuration is invalid.
+func (c RunConfig) Validate() error {
+	if c.Concurrency < 0 {
+		return errors.Errorf("invalid run concurrency %d: must not be negative", c.Concurrency)
+	}
+	if _, err := c.TimeoutDuration(); err != nil {
+		return err
+	}
+	if c.MemoryLimit != "" && !memoryLimitRegexp.MatchString(c.MemoryLimit) {
+		return errors.Errorf("invalid run memory limit %q: must be a number of bytes with an optional unit suffix (B, KiB, MiB, GiB or TiB)", c.MemoryLimit)
+	}
+	if c.GCPercent != nil && *c.GCPercent < 0 {
+		return errors.Errorf("invalid run GC percent %d: must not be negative", *c.GCPercent)
+	}
+	return nil
+}
+
+// TimeoutDuration returns the parsed value of Timeout, or 0 if it is empty.
+func (c RunConfig) TimeoutDuration() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid run timeout %q", c.Timeout)
+	}
+	if timeout < 0 {
+		return 0, errors.Errorf("invalid run timeout %q: must not be negative", c.Timeout)
+	}
+	return timeout, nil
+}
+
+// Args returns the golangci-lint "run" flags that correspond to the configuration. The configuration must be valid.
+func (c RunConfig) Args() []string {
+	var args []string
+	if c.Concurrency > 0 {
+		args = append(args, fmt.Sprintf("--concurrency=%d", c.Concurrency))
+	}
+	if timeout, _ := c.TimeoutDuration(); timeout > 0 {
+		args = append(args, fmt.Sprintf("--timeout=%s", timeout))
+	}
+	return args
+}
+
+// Env returns the environment variables of the golangci-lint process that correspond to the configuration, in the
+// form "key=value".
+func (c RunConfig) Env() []string {
+	var env []string
+	if c.MemoryLimit != "" {
+		env = append(env, "GOMEMLIMIT="+c.MemoryLimit)
+	}
+	if c.GCPercent != nil {
+		env = append(env, fmt.Sprintf("GOGC=%d", *c.GCPercent))
+	}
+	return env
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConfig(t *testing.T) {
+	gcPercent := func(val int) *int {
+		return &val
+	}
+	for i, tc := range []struct {
+		name     string
+		cfg      RunConfig
+		env      map[string]string
+		wantArgs []string
+		wantEnv  []string
+		wantErr  string
+	}{
+		{
+			name: "empty configuration",
+		},
+		{
+			name: "configuration is translated into flags and environment variables",
+			cfg: RunConfig{
+				Concurrency: 4,
+				Timeout:     "5m",
+				MemoryLimit: "4GiB",
+				GCPercent:   gcPercent(50),
+			},
+			wantArgs: []string{"--concurrency=4", "--timeout=5m0s"},
+			wantEnv:  []string{"GOMEMLIMIT=4GiB", "GOGC=50"},
+		},
+		{
+			name: "environment variables override the configuration",
+			cfg: RunConfig{
+				Concurrency: 4,
+				Timeout:     "5m",
+				GCPercent:   gcPercent(50),
+			},
+			env: map[string]string{
+				RunConcurrencyEnvVar: "2",
+				RunTimeoutEnvVar:     "90s",
+				RunMemoryLimitEnvVar: "512MiB",
+				RunGCPercentEnvVar:   "0",
+			},
+			wantArgs: []string{"--concurrency=2", "--timeout=1m30s"},
+			wantEnv:  []string{"GOMEMLIMIT=512MiB", "GOGC=0"},
+		},
+		{
+			name: "invalid memory limit",
+			cfg: RunConfig{
+				MemoryLimit: "4GB",
+			},
+			wantErr: `invalid run memory limit "4GB": must be a number of bytes with an optional unit suffix (B, KiB, MiB, GiB or TiB)`,
+		},
+		{
+			name: "invalid timeout",
+			env: map[string]string{
+				RunTimeoutEnvVar: "5",
+			},
+			wantErr: `invalid run timeout "5": time: missing unit in duration "5"`,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			cfg, err := ApplyRunConfigEnv(tc.cfg, func(key string) string {
+				return tc.env[key]
+			})
+			require.NoError(t, err)
+			err = cfg.Validate()
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantArgs, cfg.Args())
+			assert.Equal(t, tc.wantEnv, cfg.Env())
+		})
+	}
+}
@@ -23,6 +23,11 @@ import (
 )
 
 func main() {
+	// the plugin executable runs the golangci-lint embedded in the plugin when it is invoked by the plugin itself
+	if exitCode, ok := cmd.RunEmbeddedGolangCILint(os.Args); ok {
+		os.Exit(exitCode)
+	}
+
 	if ok := pluginapi.InfoCmd(os.Args, os.Stdout, cmd.PluginInfo); ok {
 		return
 	}
@@ -0,0 +1,203 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// baselineFormatVersion is the version of the format of baseline files. It is incremented when the format or the way
+// in which fingerprints are computed changes.
+const baselineFormatVersion = 1
+
+var (
+	// lineWordRegexp matches a line or column number in the text of an issue, such as "line 12" or "column 3".
+	lineWordRegexp = regexp.MustCompile(`(?i)\b(lines?|col(?:umn)?s?)\s+\d+(?:\s*-\s*\d+)?\b`)
+
+	// lineRangeRegexp matches a range of lines that precedes the word "lines" in the text of an issue, such as
+	// "10-20 lines" (reported by dupl).
+	lineRangeRegexp = regexp.MustCompile(`\b\d+-\d+(\s+lines)\b`)
+
+	// filePositionRegexp matches a position in a Go file in the text of an issue, such as "foo.go:30-40" or
+	// "foo.go:12:3".
+	filePositionRegexp = regexp.MustCompile(`(\.go):\d+(?::\d+)?(?:-\d+)?\b`)
+)
+
+// Baseline is a record of known issues. Issues that match an entry in the baseline are not considered new.
+type Baseline struct {
+	Version int             `json:"version"`
+	Issues  []BaselineEntry `json:"issues"`
+}
+
+// BaselineEntry is the record of a single issue in a baseline.
+type BaselineEntry struct {
+	Fingerprint
+
+	// Line is the line of the issue when the baseline was written. It is informational only: it is not part of the
+	// fingerprint, so issues still match the entry when lines are added or removed above them.
+	Line int `json:"line"`
+}
+
+// Fingerprint identifies an issue in a manner that does not depend on the position of the issue in its file.
+type Fingerprint struct {
+	Linter string `json:"linter"`
+	File   string `json:"file"`
+
+	// Text is the text of the issue with line and column numbers replaced (see normalizeText).
+	Text string `json:"text"`
+
+	// SourceHash is the hex-encoded SHA-256 hash of the normalized source lines of the issue (see normalizeSource).
+	SourceHash string `json:"sourceHash"`
+}
+
+// BaselineResult is the result of comparing issues with a baseline.
+type BaselineResult struct {
+	// New are the issues that do not match an entry in the baseline.
+	New []Issue
+
+	// Baselined are the issues that match an entry in the baseline.
+	Baselined []Issue
+
+	// Fixed are the entries in the baseline that do not match any issue.
+	Fixed []BaselineEntry
+}
+
+// IssueFingerprint returns the fingerprint of the provided issue.
+func IssueFingerprint(issue Issue) Fingerprint {
+	sourceHash := sha256.Sum256([]byte(normalizeSource(issue.SourceLines)))
+	return Fingerprint{
+		Linter:     issue.FromLinter,
+		File:       issue.Pos.Filename,
+		Text:       normalizeText(issue.Text),
+		SourceHash: hex.EncodeToString(sourceHash[:]),
+	}
+}
+
+// normalizeText returns the provided issue text with the line and column numbers that some linters include in it
+// replaced by "N", so that the fingerprint of such an issue does not change when lines are added or removed above it.
+func normalizeText(text string) string {
+	text = lineWordRegexp.ReplaceAllString(text, "$1 N")
+	text = lineRangeRegexp.ReplaceAllString(text, "N$1")
+	return filePositionRegexp.ReplaceAllString(text, "$1:N")
+}
+
+// normalizeSource returns the provided source lines with leading and trailing whitespace removed and runs of
+// whitespace within each line collapsed to a single space, so that changes in indentation do not change the
+// fingerprint of an issue.
+func normalizeSource(lines []string) string {
+	normalized := make([]string, 0, len(lines))
+	for _, line := range lines {
+		normalized = append(normalized, strings.Join(strings.Fields(line), " "))
+	}
+	return strings.Join(normalized, "\n")
+}
+
+// NewBaseline returns a baseline that contains the provided issues. The entries are sorted by file and line.
+func NewBaseline(issues []Issue) Baseline {
+	entries := make([]BaselineEntry, 0, len(issues))
+	for _, issue := range issues {
+		entries = append(entries, BaselineEntry{
+			Fingerprint: IssueFingerprint(issue),
+			Line:        issue.Pos.Line,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].File != entries[j].File {
+			return entries[i].File < entries[j].File
+		}
+		if entries[i].Line != entries[j].Line {
+			return entries[i].Line < entries[j].Line
+		}
+		return entries[i].Linter < entries[j].Linter
+	})
+	return Baseline{
+		Version: baselineFormatVersion,
+		Issues:  entries,
+	}
+}
+
+// Apply compares the provided issues with the baseline. Each entry in the baseline matches at most one issue, so if a
+// file contains more issues with the same fingerprint than the baseline does, the additional issues are new.
+func (b Baseline) Apply(issues []Issue) BaselineResult {
+	remaining := make(map[Fingerprint][]BaselineEntry)
+	for _, entry := range b.Issues {
+		// the text of entries written before it was normalized is normalized so that they still match
+		fingerprint := entryFingerprint(entry)
+		remaining[fingerprint] = append(remaining[fingerprint], entry)
+	}
+
+	var result BaselineResult
+	for _, issue := range issues {
+		fingerprint := IssueFingerprint(issue)
+		if entries := remaining[fingerprint]; len(entries) > 0 {
+			remaining[fingerprint] = entries[1:]
+			result.Baselined = append(result.Baselined, issue)
+			continue
+		}
+		result.New = append(result.New, issue)
+	}
+
+	// report fixed entries in the order in which they appear in the baseline
+	for _, entry := range b.Issues {
+		fingerprint := entryFingerprint(entry)
+		if entries := remaining[fingerprint]; len(entries) > 0 {
+			result.Fixed = append(result.Fixed, entries[0])
+			remaining[fingerprint] = entries[1:]
+		}
+	}
+	return result
+}
+
+func entryFingerprint(entry BaselineEntry) Fingerprint {
+	fingerprint := entry.Fingerprint
+	fingerprint.Text = normalizeText(fingerprint.Text)
+	return fingerprint
+}
+
+// ReadBaselineFile reads the baseline in the file at the provided path.
+func ReadBaselineFile(path string) (Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Baseline{}, errors.Wrapf(err, "failed to read baseline file")
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return Baseline{}, errors.Wrapf(err, "failed to unmarshal baseline file %s", path)
+	}
+	if baseline.Version != baselineFormatVersion {
+		return Baseline{}, errors.Errorf("baseline file %s has version %d, but only version %d is supported: regenerate the baseline", path, baseline.Version, baselineFormatVersion)
+	}
+	return baseline, nil
+}
+
+// WriteBaselineFile writes the provided baseline to the file at the provided path.
+func WriteBaselineFile(path string, baseline Baseline) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal baseline")
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write baseline file")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIssue(linter, file string, line int, text, source string) Issue {
+	return Issue{
+		FromLinter:  linter,
+		Text:        text,
+		SourceLines: []string{source},
+		Pos: Position{
+			Filename: file,
+			Line:     line,
+			Column:   2,
+		},
+	}
+}
+
+func TestBaselineApply(t *testing.T) {
+	baseline := NewBaseline([]Issue{
+		testIssue("ineffassign", "foo.go", 7, "ineffectual assignment to x", "\tx = 3"),
+		testIssue("ineffassign", "foo.go", 4, "ineffectual assignment to x", "\tx := 1"),
+		testIssue("errcheck", "bar.go", 10, "Error return value is not checked", "\tos.Remove(path)"),
+		testIssue("errcheck", "bar.go", 20, "Error return value is not checked", "\tos.Remove(path)"),
+	})
+	assert.Equal(t, []int{10, 20, 4, 7}, []int{baseline.Issues[0].Line, baseline.Issues[1].Line, baseline.Issues[2].Line, baseline.Issues[3].Line})
+
+	for i, tc := range []struct {
+		name          string
+		issues        []Issue
+		wantNew       []Issue
+		wantBaselined int
+		wantFixed     []int
+	}{
+		{
+			name: "issues that moved or were re-indented match the baseline",
+			issues: []Issue{
+				testIssue("ineffassign", "foo.go", 14, "ineffectual assignment to x", "    x  :=  1"),
+				testIssue("ineffassign", "foo.go", 17, "ineffectual assignment to x", "\tx = 3"),
+				testIssue("errcheck", "bar.go", 1, "Error return value is not checked", "\tos.Remove(path)"),
+				testIssue("errcheck", "bar.go", 2, "Error return value is not checked", "\tos.Remove(path)"),
+			},
+			wantBaselined: 4,
+		},
+		{
+			name: "issues with a different source line, file or linter are new",
+			issues: []Issue{
+				testIssue("ineffassign", "foo.go", 4, "ineffectual assignment to x", "\tx := 2"),
+				testIssue("ineffassign", "baz.go", 7, "ineffectual assignment to x", "\tx = 3"),
+				testIssue("wastedassign", "foo.go", 7, "ineffectual assignment to x", "\tx = 3"),
+			},
+			wantNew: []Issue{
+				testIssue("ineffassign", "foo.go", 4, "ineffectual assignment to x", "\tx := 2"),
+				testIssue("ineffassign", "baz.go", 7, "ineffectual assignment to x", "\tx = 3"),
+				testIssue("wastedassign", "foo.go", 7, "ineffectual assignment to x", "\tx = 3"),
+			},
+			wantFixed: []int{10, 20, 4, 7},
+		},
+		{
+			name: "additional issues with the same fingerprint as a baselined issue are new",
+			issues: []Issue{
+				testIssue("errcheck", "bar.go", 10, "Error return value is not checked", "\tos.Remove(path)"),
+				testIssue("errcheck", "bar.go", 20, "Error return value is not checked", "\tos.Remove(path)"),
+				testIssue("errcheck", "bar.go", 30, "Error return value is not checked", "\tos.Remove(path)"),
+			},
+			wantNew: []Issue{
+				testIssue("errcheck", "bar.go", 30, "Error return value is not checked", "\tos.Remove(path)"),
+			},
+			wantBaselined: 2,
+			wantFixed:     []int{4, 7},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			result := baseline.Apply(tc.issues)
+			assert.Equal(t, tc.wantNew, result.New)
+			assert.Len(t, result.Baselined, tc.wantBaselined)
+			var gotFixed []int
+			for _, entry := range result.Fixed {
+				gotFixed = append(gotFixed, entry.Line)
+			}
+			assert.Equal(t, tc.wantFixed, gotFixed)
+		})
+	}
+}
+
+func TestBaselineApplyIgnoresLineNumbersInText(t *testing.T) {
+	baseline := NewBaseline([]Issue{
+		testIssue("dupl", "foo.go", 10, "10-20 lines are duplicate of `foo.go:30-40`", "func foo() {"),
+		testIssue("gocritic", "foo.go", 50, "dupBranchBody: both branches in if statement have same body (line 52)", "\tif x {"),
+	})
+	assert.Equal(t, "N lines are duplicate of `foo.go:N`", baseline.Issues[0].Text)
+
+	// the issues moved down by 5 lines
+	result := baseline.Apply([]Issue{
+		testIssue("dupl", "foo.go", 15, "15-25 lines are duplicate of `foo.go:35-45`", "func foo() {"),
+		testIssue("gocritic", "foo.go", 55, "dupBranchBody: both branches in if statement have same body (line 57)", "\tif x {"),
+	})
+	assert.Empty(t, result.New)
+	assert.Len(t, result.Baselined, 2)
+	assert.Empty(t, result.Fixed)
+
+	// entries whose text was written before it was normalized still match
+	legacy := Baseline{
+		Version: baselineFormatVersion,
+		Issues: []BaselineEntry{
+			{
+				Fingerprint: Fingerprint{
+					Linter:     "dupl",
+					File:       "foo.go",
+					Text:       "10-20 lines are duplicate of `foo.go:30-40`",
+					SourceHash: baseline.Issues[0].SourceHash,
+				},
+				Line: 10,
+			},
+		},
+	}
+	result = legacy.Apply([]Issue{
+		testIssue("dupl", "foo.go", 15, "15-25 lines are duplicate of `foo.go:35-45`", "func foo() {"),
+	})
+	assert.Empty(t, result.New)
+	assert.Empty(t, result.Fixed)
+}
+
+func TestBaselineFile(t *testing.T) {
+	baselineFile := filepath.Join(t.TempDir(), "baseline.json")
+	baseline := NewBaseline([]Issue{
+		testIssue("ineffassign", "foo.go", 4, "ineffectual assignment to x", "\tx := 1"),
+	})
+	require.NoError(t, WriteBaselineFile(baselineFile, baseline))
+
+	got, err := ReadBaselineFile(baselineFile)
+	require.NoError(t, err)
+	assert.Equal(t, baseline, got)
+
+	require.NoError(t, WriteBaselineFile(baselineFile, Baseline{Version: 0}))
+	_, err = ReadBaselineFile(baselineFile)
+	assert.EqualError(t, err, "baseline file "+baselineFile+" has version 0, but only version 1 is supported: regenerate the baseline")
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Outcome describes the result of a golangci-lint run based on its exit code (see ClassifyExitCode).
+type Outcome string
+
+const (
+	// OutcomeSuccess indicates that golangci-lint ran successfully and found no issues.
+	OutcomeSuccess Outcome = "success"
+
+	// OutcomeIssuesFound indicates that golangci-lint ran successfully and found issues.
+	OutcomeIssuesFound Outcome = "issues-found"
+
+	// OutcomeFailure indicates that golangci-lint failed to lint the project (for example, because a linter panicked
+	// or packages could not be loaded).
+	OutcomeFailure Outcome = "failure"
+
+	// OutcomeTimeout indicates that golangci-lint did not finish before the timeout was exceeded.
+	OutcomeTimeout Outcome = "timeout"
+
+	// OutcomeNoGoFiles indicates that there were no Go files to lint.
+	OutcomeNoGoFiles Outcome = "no-go-files"
+
+	// OutcomeConfigError indicates that golangci-lint did not run because its configuration is invalid.
+	OutcomeConfigError Outcome = "config-error"
+
+	// OutcomeInterrupted indicates that golangci-lint was stopped by a signal. It always fails the run.
+	OutcomeInterrupted Outcome = "interrupted"
+)
+
+// ExitCodeFailure is the exit code of golangci-lint when it fails to run, which includes both failures to lint the
+// project and invalid configurations.
+const ExitCodeFailure = 3
+
+// Exit codes of golangci-lint (see github.com/golangci/golangci-lint/v2/pkg/exitcodes) other than
+// ExitCodeIssuesFound and ExitCodeFailure.
+const (
+	exitCodeTimeout              = 4
+	exitCodeNoGoFiles            = 5
+	exitCodeNoConfigFileDetected = 6
+)
+
+// FailOnOutcomes are the outcomes that can fail a run, which are the outcomes that can be specified in the "exit"
+// section of the plugin configuration. All of them fail a run by default.
+var FailOnOutcomes = []Outcome{
+	OutcomeIssuesFound,
+	OutcomeFailure,
+	OutcomeTimeout,
+	OutcomeNoGoFiles,
+	OutcomeConfigError,
+}
+
+// ClassifyExitCode returns the outcome of a golangci-lint run that exited with the provided exit code. golangci-lint
+// uses the same exit code (ExitCodeFailure) for configuration errors and other failures, so a run that fails because
+// its configuration is invalid is classified as OutcomeFailure: the lint command verifies the configuration once such
+// a run failed to report OutcomeConfigError instead (see the "config verify" command of golangci-lint).
+func ClassifyExitCode(exitCode int) Outcome {
+	switch {
+	case exitCode == 0:
+		return OutcomeSuccess
+	case exitCode == ExitCodeIssuesFound:
+		return OutcomeIssuesFound
+	case exitCode == exitCodeTimeout:
+		return OutcomeTimeout
+	case exitCode == exitCodeNoGoFiles:
+		return OutcomeNoGoFiles
+	case exitCode == exitCodeNoConfigFileDetected:
+		return OutcomeConfigError
+	case exitCode > 128:
+		return OutcomeInterrupted
+	}
+	return OutcomeFailure
+}
+
+// Summary returns a one-line summary of the outcome of a run that exited with the provided exit code.
+func (o Outcome) Summary(exitCode int) string {
+	var summary string
+	switch o {
+	case OutcomeSuccess:
+		summary = "golangci-lint found no issues"
+	case OutcomeIssuesFound:
+		summary = "golangci-lint found issues"
+	case OutcomeTimeout:
+		summary = "golangci-lint did not finish before the timeout was exceeded"
+	case OutcomeNoGoFiles:
+		summary = "golangci-lint found no Go files to lint"
+	case OutcomeConfigError:
+		summary = "golangci-lint did not run because its configuration is invalid (run \"./godelw doctor\" to diagnose the configuration)"
+	case OutcomeInterrupted:
+		summary = "golangci-lint was interrupted"
+	default:
+		summary = "golangci-lint failed to run (this is not caused by issues in the code: see the errors above)"
+	}
+	return fmt.Sprintf("%s: %s (exit code %d)", o, summary, exitCode)
+}
+
+// FailPolicy determines which outcomes fail a run.
+type FailPolicy struct {
+	failOn map[Outcome]bool
+}
+
+// NewFailPolicy returns the policy under which only the provided outcomes (and OutcomeInterrupted) fail a run. If no
+// outcomes are provided, all of the FailOnOutcomes fail a run. Returns an error if an outcome is not one of the
+// FailOnOutcomes.
+func NewFailPolicy(failOn []string) (FailPolicy, error) {
+	if len(failOn) == 0 {
+		failOn = make([]string, 0, len(FailOnOutcomes))
+		for _, outcome := range FailOnOutcomes {
+			failOn = append(failOn, string(outcome))
+		}
+	}
+	policy := FailPolicy{
+		failOn: make(map[Outcome]bool),
+	}
+	for _, name := range failOn {
+		outcome, ok := failOnOutcome(name)
+		if !ok {
+			validNames := make([]string, 0, len(FailOnOutcomes))
+			for _, outcome := range FailOnOutcomes {
+				validNames = append(validNames, string(outcome))
+			}
+			return FailPolicy{}, errors.Errorf("invalid outcome %q: must be one of %s", name, strings.Join(validNames, ", "))
+		}
+		policy.failOn[outcome] = true
+	}
+	return policy, nil
+}
+
+// Fails returns true if the provided outcome fails a run under the policy. OutcomeSuccess never fails a run and
+// OutcomeInterrupted always fails a run. The zero value fails a run for every outcome other than OutcomeSuccess.
+func (p FailPolicy) Fails(outcome Outcome) bool {
+	switch {
+	case outcome == OutcomeSuccess:
+		return false
+	case outcome == OutcomeInterrupted || p.failOn == nil:
+		return true
+	}
+	return p.failOn[outcome]
+}
+
+func failOnOutcome(name string) (Outcome, bool) {
+	for _, outcome := range FailOnOutcomes {
+		if string(outcome) == name {
+			return outcome, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyExitCode(t *testing.T) {
+	for i, tc := range []struct {
+		name     string
+		exitCode int
+		want     Outcome
+	}{
+		{
+			name: "success",
+			want: OutcomeSuccess,
+		},
+		{
+			name:     "issues found",
+			exitCode: 1,
+			want:     OutcomeIssuesFound,
+		},
+		{
+			name:     "failure",
+			exitCode: 3,
+			want:     OutcomeFailure,
+		},
+		{
+			name:     "invalid configuration is not distinguished from other failures",
+			exitCode: 3,
+			want:     OutcomeFailure,
+		},
+		{
+			name:     "timeout",
+			exitCode: 4,
+			want:     OutcomeTimeout,
+		},
+		{
+			name:     "no Go files",
+			exitCode: 5,
+			want:     OutcomeNoGoFiles,
+		},
+		{
+			name:     "no configuration file",
+			exitCode: 6,
+			want:     OutcomeConfigError,
+		},
+		{
+			name:     "error was logged",
+			exitCode: 7,
+			want:     OutcomeFailure,
+		},
+		{
+			name:     "panic",
+			exitCode: 2,
+			want:     OutcomeFailure,
+		},
+		{
+			name:     "interrupted",
+			exitCode: 130,
+			want:     OutcomeInterrupted,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			assert.Equal(t, tc.want, ClassifyExitCode(tc.exitCode))
+		})
+	}
+}
+
+func TestOutcomeSummary(t *testing.T) {
+	assert.Equal(t, "issues-found: golangci-lint found issues (exit code 1)", OutcomeIssuesFound.Summary(1))
+	assert.Equal(t, "timeout: golangci-lint did not finish before the timeout was exceeded (exit code 4)", OutcomeTimeout.Summary(4))
+}
+
+func TestFailPolicy(t *testing.T) {
+	for i, tc := range []struct {
+		name     string
+		failOn   []string
+		wantFail []Outcome
+		wantPass []Outcome
+	}{
+		{
+			name:     "all outcomes fail by default",
+			wantFail: []Outcome{OutcomeIssuesFound, OutcomeFailure, OutcomeTimeout, OutcomeNoGoFiles, OutcomeConfigError, OutcomeInterrupted},
+			wantPass: []Outcome{OutcomeSuccess},
+		},
+		{
+			name:     "only the specified outcomes fail",
+			failOn:   []string{"failure", "config-error"},
+			wantFail: []Outcome{OutcomeFailure, OutcomeConfigError, OutcomeInterrupted},
+			wantPass: []Outcome{OutcomeSuccess, OutcomeIssuesFound, OutcomeTimeout, OutcomeNoGoFiles},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			policy, err := NewFailPolicy(tc.failOn)
+			require.NoError(t, err)
+			for _, outcome := range tc.wantFail {
+				assert.True(t, policy.Fails(outcome), outcome)
+			}
+			for _, outcome := range tc.wantPass {
+				assert.False(t, policy.Fails(outcome), outcome)
+			}
+		})
+	}
+
+	_, err := NewFailPolicy([]string{"interrupted"})
+	assert.EqualError(t, err, `invalid outcome "interrupted": must be one of issues-found, failure, timeout, no-go-files, config-error`)
+
+	// the zero value fails on every outcome other than success
+	assert.True(t, FailPolicy{}.Fails(OutcomeNoGoFiles))
+	assert.False(t, FailPolicy{}.Fails(OutcomeSuccess))
+}
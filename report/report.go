@@ -0,0 +1,166 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report provides a structured model of the issues reported by golangci-lint. The model is parsed from the
+// output of the golangci-lint JSON printer, which allows the plugin to reason about the results of a run (rather than
+// passing the output of golangci-lint through as-is) and to print the results itself.
+package report
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Report is the result of a golangci-lint run.
+type Report struct {
+	// Issues are the issues reported by golangci-lint in the order in which they were reported.
+	Issues []Issue
+
+	// Warnings are the warnings logged by golangci-lint during the run.
+	Warnings []Warning
+
+	// Linters are the linters known to golangci-lint, along with whether they were enabled for the run.
+	Linters []Linter
+
+	// Error is the error that caused the run to fail. Empty if the run succeeded.
+	Error string
+}
+
+// Issue is a single issue reported by a linter.
+type Issue struct {
+	// FromLinter is the name of the linter that reported the issue.
+	FromLinter string
+
+	// Text is the description of the issue.
+	Text string
+
+	// Severity is the severity of the issue. Empty unless severities are configured.
+	Severity string
+
+	// SourceLines are the lines of source code that contain the issue.
+	SourceLines []string
+
+	// Pos is the position of the issue.
+	Pos Position
+
+	// LineRange is the range of lines that contain the issue. Nil if the issue is on a single line.
+	LineRange *LineRange
+
+	// SuggestedFixes are the fixes suggested by the linter for the issue. Empty if the linter does not suggest a fix.
+	SuggestedFixes []SuggestedFix
+
+	// ExpectNoLint is true if the issue was reported by nolintlint for a "nolint" directive that is expected to
+	// suppress an issue from ExpectedNoLintLinter.
+	ExpectNoLint         bool
+	ExpectedNoLintLinter string
+}
+
+// Fixable returns true if the issue can be fixed automatically by running golangci-lint with "--fix", which applies
+// the edits of the fixes suggested by linters. Suggested fixes that only have a message and no edits do not make an
+// issue fixable.
+func (i Issue) Fixable() bool {
+	for _, fix := range i.SuggestedFixes {
+		if len(fix.TextEdits) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Position is the location of an issue in a file.
+type Position struct {
+	// Filename is the path to the file, formatted according to the "run.relative-path-mode" configuration.
+	Filename string
+
+	// Offset is the byte offset of the position in the file, starting at 0.
+	Offset int
+
+	// Line is the line number of the position, starting at 1.
+	Line int
+
+	// Column is the column number of the position in bytes, starting at 1. 0 if the column is unknown.
+	Column int
+}
+
+// LineRange is an inclusive range of line numbers.
+type LineRange struct {
+	From, To int
+}
+
+// SuggestedFix is a fix for an issue that is suggested by a linter.
+type SuggestedFix struct {
+	// Message describes the fix.
+	Message string
+
+	// TextEdits are the edits that apply the fix.
+	TextEdits []TextEdit
+}
+
+// TextEdit replaces the text between Pos and End with NewText. Pos and End are positions in the file set used by the
+// linter, so they are only meaningful relative to each other.
+type TextEdit struct {
+	Pos     int
+	End     int
+	NewText []byte
+}
+
+// Warning is a warning logged by golangci-lint.
+type Warning struct {
+	Tag  string
+	Text string
+}
+
+// Linter is a linter known to golangci-lint.
+type Linter struct {
+	Name    string
+	Enabled bool
+}
+
+// jsonOutput is the format of the output written by the golangci-lint JSON printer.
+type jsonOutput struct {
+	Issues []Issue
+	Report *struct {
+		Warnings []Warning
+		Linters  []Linter
+		Error    string
+	}
+}
+
+// ParseJSON parses the provided output of the golangci-lint JSON printer.
+func ParseJSON(data []byte) (*Report, error) {
+	var output jsonOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal golangci-lint JSON output")
+	}
+	report := &Report{
+		Issues: output.Issues,
+	}
+	if output.Report != nil {
+		report.Warnings = output.Report.Warnings
+		report.Linters = output.Report.Linters
+		report.Error = output.Report.Error
+	}
+	return report, nil
+}
+
+// ReadJSONFile parses the output of the golangci-lint JSON printer in the file at the provided path.
+func ReadJSONFile(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read golangci-lint JSON output")
+	}
+	return ParseJSON(data)
+}
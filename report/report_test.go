@@ -0,0 +1,169 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJSONOutput = `{"Issues":[{"FromLinter":"ineffassign","Text":"ineffectual assignment to x","Severity":"","SourceLines":["\tx := 1"],"Pos":{"Filename":"sub/sub.go","Offset":29,"Line":4,"Column":2},"ExpectNoLint":false,"ExpectedNoLintLinter":""},{"FromLinter":"godot","Text":"Comment should end in a period","Severity":"warning","SourceLines":["// Foo does things"],"Pos":{"Filename":"foo.go","Offset":14,"Line":3,"Column":0},"SuggestedFixes":[{"Message":"","TextEdits":[{"Pos":31,"End":31,"NewText":"Lg=="}]}],"ExpectNoLint":false,"ExpectedNoLintLinter":""}],"Report":{"Warnings":[{"Tag":"runner","Text":"example warning"}],"Linters":[{"Name":"asasalint"},{"Name":"godot","Enabled":true},{"Name":"ineffassign","Enabled":true}]}}`
+
+func TestParseJSON(t *testing.T) {
+	got, err := ParseJSON([]byte(testJSONOutput))
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		Issues: []Issue{
+			{
+				FromLinter:  "ineffassign",
+				Text:        "ineffectual assignment to x",
+				SourceLines: []string{"\tx := 1"},
+				Pos: Position{
+					Filename: "sub/sub.go",
+					Offset:   29,
+					Line:     4,
+					Column:   2,
+				},
+			},
+			{
+				FromLinter:  "godot",
+				Text:        "Comment should end in a period",
+				Severity:    "warning",
+				SourceLines: []string{"// Foo does things"},
+				Pos: Position{
+					Filename: "foo.go",
+					Offset:   14,
+					Line:     3,
+				},
+				SuggestedFixes: []SuggestedFix{
+					{
+						TextEdits: []TextEdit{
+							{
+								Pos:     31,
+								End:     31,
+								NewText: []byte("."),
+							},
+						},
+					},
+				},
+			},
+		},
+		Warnings: []Warning{
+			{
+				Tag:  "runner",
+				Text: "example warning",
+			},
+		},
+		Linters: []Linter{
+			{Name: "asasalint"},
+			{Name: "godot", Enabled: true},
+			{Name: "ineffassign", Enabled: true},
+		},
+	}, got)
+}
+
+func TestParseJSONInvalid(t *testing.T) {
+	_, err := ParseJSON([]byte("level=error msg=\"Running error\""))
+	assert.EqualError(t, err, "failed to unmarshal golangci-lint JSON output: invalid character 'l' looking for beginning of value")
+}
+
+func TestPrintText(t *testing.T) {
+	lintReport, err := ParseJSON([]byte(testJSONOutput))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	PrintText(&buf, lintReport.Issues)
+	assert.Equal(t, "sub/sub.go:4:2: ineffectual assignment to x (ineffassign)\n"+
+		"\tx := 1\n"+
+		"\t^\n"+
+		"foo.go:3: Comment should end in a period (godot)\n"+
+		"// Foo does things\n", buf.String())
+}
+
+func TestPrintStats(t *testing.T) {
+	lintReport, err := ParseJSON([]byte(testJSONOutput))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	PrintStats(&buf, append(lintReport.Issues, lintReport.Issues[0]))
+	assert.Equal(t, "3 issues:\n* godot: 1\n* ineffassign: 2\n", buf.String())
+
+	buf.Reset()
+	PrintStats(&buf, nil)
+	assert.Equal(t, "0 issues.\n", buf.String())
+}
+
+func TestPrintTextFixableFirst(t *testing.T) {
+	lintReport, err := ParseJSON([]byte(testJSONOutput))
+	require.NoError(t, err)
+	assert.False(t, lintReport.Issues[0].Fixable())
+	assert.True(t, lintReport.Issues[1].Fixable())
+
+	var buf bytes.Buffer
+	PrintTextFixableFirst(&buf, lintReport.Issues)
+	assert.Equal(t, "foo.go:3: Comment should end in a period (godot, auto-fixable)\n"+
+		"// Foo does things\n"+
+		"sub/sub.go:4:2: ineffectual assignment to x (ineffassign)\n"+
+		"\tx := 1\n"+
+		"\t^\n", buf.String())
+}
+
+func TestIssueFixable(t *testing.T) {
+	for i, tc := range []struct {
+		name  string
+		fixes []SuggestedFix
+		want  bool
+	}{
+		{
+			name: "no suggested fixes",
+		},
+		{
+			name: "suggested fix with only a message",
+			fixes: []SuggestedFix{
+				{Message: "consider renaming"},
+			},
+		},
+		{
+			name: "suggested fix with text edits",
+			fixes: []SuggestedFix{
+				{Message: "consider renaming"},
+				{TextEdits: []TextEdit{{Pos: 1, End: 2, NewText: []byte("x")}}},
+			},
+			want: true,
+		},
+	} {
+		assert.Equal(t, tc.want, Issue{SuggestedFixes: tc.fixes}.Fixable(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestPrintFixableSummary(t *testing.T) {
+	lintReport, err := ParseJSON([]byte(testJSONOutput))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	PrintFixableSummary(&buf, append(lintReport.Issues, lintReport.Issues[0]))
+	assert.Equal(t, "1 of 3 issues are auto-fixable (run \"./godelw verify --apply=true\" or \"./godelw lint --fix\" to fix them)\n", buf.String())
+
+	buf.Reset()
+	PrintFixableSummary(&buf, lintReport.Issues[:1])
+	assert.Equal(t, "0 of 1 issues are auto-fixable\n", buf.String())
+
+	buf.Reset()
+	PrintFixableSummary(&buf, nil)
+	assert.Equal(t, "", buf.String())
+}
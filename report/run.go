@@ -0,0 +1,78 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// ExitCodeIssuesFound is the exit code of golangci-lint when issues are found. The "run.issues-exit-code"
+// configuration can change it, so the lint command overrides it using IssuesExitCodeArg.
+const ExitCodeIssuesFound = 1
+
+// IssuesExitCodeArg is the golangci-lint flag that sets the exit code used when issues are found to
+// ExitCodeIssuesFound regardless of the "run.issues-exit-code" configuration, so that the exit code of a run can be
+// classified (see ClassifyExitCode).
+var IssuesExitCodeArg = "--issues-exit-code=" + strconv.Itoa(ExitCodeIssuesFound)
+
+// UncappedIssuesArgs are the golangci-lint flags that disable the limits on the number of issues reported per linter
+// and on the number of identical issues reported (by default, golangci-lint reports at most 50 issues per linter and 3
+// identical issues). They are used when every issue must be reported, such as when writing or applying a baseline.
+var UncappedIssuesArgs = []string{
+	"--max-issues-per-linter=0",
+	"--max-same-issues=0",
+}
+
+// RunFunc runs golangci-lint with the provided output arguments added to its "run" arguments and returns its exit code.
+type RunFunc func(outputArgs []string) (int, error)
+
+// Run runs golangci-lint using the provided function so that the issues are written by the golangci-lint JSON printer
+// to a file in a temporary directory rather than being printed, and returns the report parsed from that file along
+// with the exit code of golangci-lint. The per-linter statistics printed by golangci-lint are also disabled so that
+// the caller can print the results (see PrintText and PrintStats).
+//
+// If golangci-lint exits before writing the JSON output (for example, because the configuration is invalid), the
+// returned report is nil: golangci-lint logs the cause of the failure to stderr in this case.
+func Run(run RunFunc) (*Report, int, error) {
+	outputDir, err := os.MkdirTemp("", "golangci-lint-plugin-report-*")
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to create directory for golangci-lint JSON output")
+	}
+	defer func() {
+		_ = os.RemoveAll(outputDir)
+	}()
+
+	jsonOutputPath := filepath.Join(outputDir, "report.json")
+	exitCode, err := run([]string{
+		"--output.json.path", jsonOutputPath,
+		"--show-stats=false",
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := os.Stat(jsonOutputPath); os.IsNotExist(err) {
+		return nil, exitCode, nil
+	}
+	report, err := ReadJSONFile(jsonOutputPath)
+	if err != nil {
+		return nil, exitCode, err
+	}
+	return report, exitCode, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// PrintText writes the provided issues to the provided writer in the format used by the golangci-lint text printer:
+// the position, text and linter of each issue is followed by the source lines of the issue and, if the issue is on a
+// single line and its column is known, a line with a pointer to the column.
+func PrintText(w io.Writer, issues []Issue) {
+	for _, issue := range issues {
+		printIssue(w, issue, issue.FromLinter)
+	}
+}
+
+// PrintTextFixableFirst writes the provided issues to the provided writer in the same format as PrintText, except that
+// the issues that can be fixed automatically (see Issue.Fixable) are written first and are marked as auto-fixable.
+func PrintTextFixableFirst(w io.Writer, issues []Issue) {
+	fixable, other := PartitionFixable(issues)
+	for _, issue := range fixable {
+		printIssue(w, issue, issue.FromLinter+", auto-fixable")
+	}
+	PrintText(w, other)
+}
+
+// PrintFixableSummary writes the number of the provided issues that can be fixed automatically to the provided
+// writer. Nothing is written if there are no issues.
+func PrintFixableSummary(w io.Writer, issues []Issue) {
+	if len(issues) == 0 {
+		return
+	}
+	fixable, _ := PartitionFixable(issues)
+	if len(fixable) == 0 {
+		_, _ = fmt.Fprintf(w, "0 of %d issues are auto-fixable\n", len(issues))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%d of %d issues are auto-fixable (run \"./godelw verify --apply=true\" or \"./godelw lint --fix\" to fix them)\n", len(fixable), len(issues))
+}
+
+// PartitionFixable returns the provided issues that can be fixed automatically (see Issue.Fixable) and the other
+// issues, in the order in which they were provided.
+func PartitionFixable(issues []Issue) (fixable, other []Issue) {
+	for _, issue := range issues {
+		if issue.Fixable() {
+			fixable = append(fixable, issue)
+		} else {
+			other = append(other, issue)
+		}
+	}
+	return fixable, other
+}
+
+// printIssue writes the provided issue followed by the provided annotation in parentheses.
+func printIssue(w io.Writer, issue Issue, annotation string) {
+	_, _ = fmt.Fprintf(w, "%s: %s (%s)\n", issue.Pos, strings.TrimSpace(issue.Text), annotation)
+	for _, line := range issue.SourceLines {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	if pointer, ok := underlinePointer(issue); ok {
+		_, _ = fmt.Fprintln(w, pointer)
+	}
+}
+
+// PrintStats writes the number of issues reported by each linter to the provided writer in the format used by
+// golangci-lint.
+func PrintStats(w io.Writer, issues []Issue) {
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintln(w, "0 issues.")
+		return
+	}
+
+	stats := make(map[string]int)
+	for _, issue := range issues {
+		stats[issue.FromLinter]++
+	}
+	linters := make([]string, 0, len(stats))
+	for linter := range stats {
+		linters = append(linters, linter)
+	}
+	sort.Strings(linters)
+
+	_, _ = fmt.Fprintf(w, "%d issues:\n", len(issues))
+	for _, linter := range linters {
+		_, _ = fmt.Fprintf(w, "* %s: %d\n", linter, stats[linter])
+	}
+}
+
+// String returns the position in the form "file:line:column" (or "file:line" if the column is unknown).
+func (p Position) String() string {
+	if p.Column == 0 {
+		return fmt.Sprintf("%s:%d", p.Filename, p.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// underlinePointer returns a line that points to the column of the provided issue in its source line. Tabs in the
+// source line before the column are preserved so that the pointer is aligned when the output is displayed.
+func underlinePointer(issue Issue) (string, bool) {
+	if len(issue.SourceLines) != 1 || issue.Pos.Column == 0 {
+		return "", false
+	}
+	line := issue.SourceLines[0]
+	var prefix strings.Builder
+	for i := 0; i < len(line) && i < issue.Pos.Column-1; i++ {
+		if line[i] == '\t' {
+			prefix.WriteByte('\t')
+		} else {
+			prefix.WriteByte(' ')
+		}
+	}
+	return prefix.String() + "^", true
+}
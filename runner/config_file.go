@@ -0,0 +1,136 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// EditorConfigFileName is the name of the file in ConfigDir that always contains the configuration most recently
+	// written by WriteConfigFile. Editors and tools that run golangci-lint directly can use this file to lint using the
+	// same configuration as the plugin.
+	EditorConfigFileName = ".golangci.yml"
+
+	// staleConfigFileAge is the age after which configuration files in ConfigDir that were not used are removed.
+	staleConfigFileAge = 7 * 24 * time.Hour
+)
+
+// OutDir is the godel output directory (relative to the project directory), which contains ConfigDir.
+const OutDir = "out"
+
+// ConfigDir is the directory (relative to the project directory) to which WriteConfigFile writes configuration files.
+var ConfigDir = filepath.Join(OutDir, "golangci-lint")
+
+// ConfigFilePath returns the path of the file to which WriteConfigFile writes the provided configuration in the
+// provided project directory. The name of the file is derived from the SHA-256 checksum of the configuration, so the
+// path only changes when the configuration changes.
+func ConfigFilePath(projectDir string, configContent []byte) string {
+	sum := sha256.Sum256(configContent)
+	return filepath.Join(projectDir, ConfigDir, hex.EncodeToString(sum[:])[:16]+".yml")
+}
+
+// WriteConfigFile writes the provided configuration to the file returned by ConfigFilePath (if it does not already
+// exist) and returns its path. The path is stable across runs with the same configuration, which allows golangci-lint
+// to reuse cached results that are keyed on the path of the configuration file. If updateEditorConfig is true, the
+// configuration is also written to the EditorConfigFileName file in the same directory (this should only be done for
+// the configuration of the project directory rather than that of a nested module). Configuration files that have not
+// been used for a week are removed.
+func WriteConfigFile(projectDir string, configContent []byte, updateEditorConfig bool) (string, error) {
+	configFilePath, err := filepath.Abs(ConfigFilePath(projectDir, configContent))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to determine absolute path of config file")
+	}
+	configDir := filepath.Dir(configFilePath)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", errors.Wrapf(err, "failed to create directory %s", configDir)
+	}
+
+	if existing, err := os.ReadFile(configFilePath); err == nil && bytes.Equal(existing, configContent) {
+		// record that the file was used so that it is not removed as stale
+		now := time.Now()
+		_ = os.Chtimes(configFilePath, now, now)
+	} else if err := writeFileAtomic(configFilePath, configContent); err != nil {
+		return "", err
+	}
+
+	if updateEditorConfig {
+		editorConfigFilePath := filepath.Join(configDir, EditorConfigFileName)
+		if existing, err := os.ReadFile(editorConfigFilePath); err != nil || !bytes.Equal(existing, configContent) {
+			if err := writeFileAtomic(editorConfigFilePath, configContent); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	removeStaleConfigFiles(configDir, configFilePath)
+	return configFilePath, nil
+}
+
+// writeFileAtomic writes the provided content to the file at the provided path by writing it to a temporary file in
+// the same directory and renaming it, so that concurrent runs never read a partially written file.
+func writeFileAtomic(path string, content []byte) (rErr error) {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temp file")
+	}
+	defer func() {
+		if rErr != nil {
+			_ = os.Remove(tmpFile.Name())
+		}
+	}()
+	if _, err := tmpFile.Write(content); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to write to temp file")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close temp file")
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return errors.Wrapf(err, "failed to set permissions of %s", tmpFile.Name())
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return errors.Wrapf(err, "failed to write %s", path)
+	}
+	return nil
+}
+
+// removeStaleConfigFiles removes the configuration files in the provided directory other than the provided one that
+// have not been used for staleConfigFileAge. Failures are ignored: stale files are only removed to save space.
+func removeStaleConfigFiles(configDir, currentConfigFilePath string) {
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(configDir, entry.Name())
+		if entry.IsDir() || path == currentConfigFilePath || strings.HasPrefix(entry.Name(), ".") || filepath.Ext(entry.Name()) != ".yml" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < staleConfigFileAge {
+			continue
+		}
+		_ = os.Remove(path)
+	}
+}
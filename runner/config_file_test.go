@@ -0,0 +1,75 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConfigFile(t *testing.T) {
+	projectDir := t.TempDir()
+	configDir := filepath.Join(projectDir, ConfigDir)
+
+	configPath, err := WriteConfigFile(projectDir, []byte("config-1"), true)
+	require.NoError(t, err)
+	assert.Equal(t, ConfigFilePath(projectDir, []byte("config-1")), configPath)
+	assertFileContent(t, configPath, "config-1")
+	assertFileContent(t, filepath.Join(configDir, EditorConfigFileName), "config-1")
+
+	// writing the same configuration again uses the same file
+	sameConfigPath, err := WriteConfigFile(projectDir, []byte("config-1"), true)
+	require.NoError(t, err)
+	assert.Equal(t, configPath, sameConfigPath)
+
+	// make the first configuration file stale
+	staleTime := time.Now().Add(-2 * staleConfigFileAge)
+	require.NoError(t, os.Chtimes(configPath, staleTime, staleTime))
+
+	// a configuration that does not update the editor file is written to a different file
+	moduleConfigPath, err := WriteConfigFile(projectDir, []byte("module-config"), false)
+	require.NoError(t, err)
+	assertFileContent(t, moduleConfigPath, "module-config")
+	assertFileContent(t, filepath.Join(configDir, EditorConfigFileName), "config-1")
+
+	// a different configuration is written to a different file and replaces the content of the editor file, and the
+	// stale configuration file is removed
+	otherConfigPath, err := WriteConfigFile(projectDir, []byte("config-2"), true)
+	require.NoError(t, err)
+	assert.NotEqual(t, configPath, otherConfigPath)
+	assertFileContent(t, otherConfigPath, "config-2")
+	assertFileContent(t, filepath.Join(configDir, EditorConfigFileName), "config-2")
+	_, err = os.Stat(configPath)
+	assert.True(t, os.IsNotExist(err), "stale config file should have been removed")
+
+	entries, err := os.ReadDir(configDir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{EditorConfigFileName, filepath.Base(moduleConfigPath), filepath.Base(otherConfigPath)}, names)
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+}
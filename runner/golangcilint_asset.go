@@ -15,62 +15,185 @@
 package runner
 
 import (
+	"context"
 	goerror "errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
-func RunGolangCILintWithConfig(pathToBinary string, preConfigArgs, postConfigArgs []string, configContent []byte, stdout, stderr io.Writer, debugMode bool) (int, error) {
-	configFilePath, err := writeTempFile("golangci-lint-plugin-config-*.yml", configContent)
+const (
+	// ExitCodeTimeout is the exit code returned when golangci-lint does not finish before the deadline of the provided
+	// context. It is the same exit code that golangci-lint uses when its own timeout is exceeded.
+	ExitCodeTimeout = 4
+
+	// gracePeriod is the time that golangci-lint is given to exit after it is signaled to stop (because the plugin was
+	// interrupted or the timeout was exceeded) before it is killed.
+	gracePeriod = 10 * time.Second
+)
+
+// RunGolangCILintWithConfig writes the provided configuration to a file in the provided project directory (see
+// WriteConfigFile) and runs golangci-lint in the provided directory with the provided arguments before and after the
+// flag that specifies the configuration file (see GolangCILintCmdRunner). The directories are the same unless
+// golangci-lint is run in a module nested in the project directory. If the configuration cannot be written to the
+// project directory (for example, because it is read-only), it is written to a temporary file instead, which is removed
+// when the run completes (unless debugMode is true), including if the run is interrupted.
+func RunGolangCILintWithConfig(ctx context.Context, pathToBinary, dir, projectDir string, env, preConfigArgs, postConfigArgs []string, configContent []byte, stdout, stderr io.Writer, debugMode bool) (int, error) {
+	// interrupts are handled before the config file is written so that a temporary file is removed if the run is
+	// interrupted
+	signals := notifyInterrupts()
+	defer signal.Stop(signals)
+
+	// the editor configuration file is only updated with the configuration of the project directory
+	configFilePath, err := WriteConfigFile(projectDir, configContent, filepath.Clean(dir) == filepath.Clean(projectDir))
 	if err != nil {
-		return 0, errors.Wrapf(err, "failed to write config file")
-	}
-	defer func() {
-		// in debug mode, do not remove the config file
 		if debugMode {
-			return
+			_, _ = fmt.Fprintf(stderr, "Failed to write config file to %s: using a temporary file instead: %v\n", filepath.Join(projectDir, ConfigDir), err)
 		}
-		_ = os.Remove(configFilePath)
-	}()
+		configFilePath, err = writeTempFile("golangci-lint-plugin-config-*.yml", configContent)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to write config file")
+		}
+		defer func() {
+			// in debug mode, do not remove the config file
+			if debugMode {
+				return
+			}
+			_ = os.Remove(configFilePath)
+		}()
+	}
 
 	args := append(preConfigArgs, "--config", configFilePath)
 	args = append(args, postConfigArgs...)
 
-	return RunGolangCILint(pathToBinary, args, stdout, stderr, debugMode), nil
+	return runCmd(ctx, newCmd(pathToBinary, dir, env, args, stdout, stderr), signals, stderr, debugMode), nil
 }
 
-func RunGolangCILint(pathToBinary string, args []string, stdout, stderr io.Writer, debugMode bool) int {
-	runner := GolangCILintCmdRunner(pathToBinary, args, stdout, stderr, debugMode)
+func RunGolangCILint(ctx context.Context, pathToBinary, dir string, env, args []string, stdout, stderr io.Writer, debugMode bool) int {
+	runner := GolangCILintCmdRunner(ctx, pathToBinary, dir, env, args, stdout, stderr, debugMode)
 	return runner()
 }
 
-func GolangCILintCmdRunner(pathToBinary string, args []string, stdout, stderr io.Writer, debugMode bool) func() int {
+// GolangCILintCmdRunner returns a function that runs the golangci-lint executable at the provided path with the
+// provided arguments in the provided directory and returns its exit code. If dir is empty, the command is run in the
+// working directory of the current process. The provided environment variables (in the form "key=value") are added to
+// the environment of the current process, which golangci-lint inherits.
+//
+// golangci-lint is run in its own process group. While it runs, SIGINT and SIGTERM received by the current process
+// are forwarded to the process group, and if the provided context is done, SIGTERM is sent to the process group. If
+// golangci-lint does not exit within a grace period after being signaled, the process group is killed. The returned
+// exit code is 128+n if the run was interrupted by signal n, or ExitCodeTimeout if the context deadline was exceeded.
+func GolangCILintCmdRunner(ctx context.Context, pathToBinary, dir string, env, args []string, stdout, stderr io.Writer, debugMode bool) func() int {
+	cmd := newCmd(pathToBinary, dir, env, args, stdout, stderr)
+	return func() int {
+		signals := notifyInterrupts()
+		defer signal.Stop(signals)
+		return runCmd(ctx, cmd, signals, stderr, debugMode)
+	}
+}
+
+func newCmd(pathToBinary, dir string, env, args []string, stdout, stderr io.Writer) *exec.Cmd {
 	cmd := exec.Command(pathToBinary, args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
+	setProcessGroup(cmd)
+	return cmd
+}
 
-	return func() int {
-		if debugMode {
-			_, _ = fmt.Fprintf(stderr, "Running \"%s\" in working directory %s\n", strings.Join(cmd.Args, " "), cmd.Dir)
+func notifyInterrupts() chan os.Signal {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	return signals
+}
+
+func runCmd(ctx context.Context, cmd *exec.Cmd, signals <-chan os.Signal, stderr io.Writer, debugMode bool) int {
+	// do not start golangci-lint if the run was interrupted before it started
+	select {
+	case sig := <-signals:
+		return signalExitCode(sig)
+	default:
+	}
+
+	if debugMode {
+		_, _ = fmt.Fprintf(stderr, "Running \"%s\" in working directory %s\n", strings.Join(cmd.Args, " "), cmd.Dir)
+	}
+	if err := cmd.Start(); err != nil {
+		_, _ = fmt.Fprintf(stderr, "command %v failed with error: %v\n", cmd.Args, errors.Wrapf(err, "run error"))
+		return 1
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var (
+		ctxDone     = ctx.Done()
+		killTimer   <-chan time.Time
+		interrupted os.Signal
+		timedOut    bool
+	)
+	stop := func(sig os.Signal) {
+		_ = signalProcessGroup(cmd, sig)
+		if killTimer == nil {
+			killTimer = time.After(gracePeriod)
 		}
-		if err := cmd.Run(); err != nil {
+	}
+	for {
+		select {
+		case err := <-done:
+			switch {
+			case interrupted != nil:
+				return signalExitCode(interrupted)
+			case timedOut:
+				_, _ = fmt.Fprintf(stderr, "golangci-lint did not finish before the timeout was exceeded\n")
+				return ExitCodeTimeout
+			case err == nil:
+				return 0
+			}
 			var exitErr *exec.ExitError
 			if errors.As(err, &exitErr) {
 				return exitErr.ExitCode()
 			}
 			_, _ = fmt.Fprintf(stderr, "command %v failed with error: %v\n", cmd.Args, errors.Wrapf(err, "run error"))
 			return 1
+		case sig := <-signals:
+			if interrupted == nil {
+				interrupted = sig
+			}
+			stop(sig)
+		case <-ctxDone:
+			ctxDone = nil
+			timedOut = true
+			stop(syscall.SIGTERM)
+		case <-killTimer:
+			_, _ = fmt.Fprintf(stderr, "golangci-lint did not exit within %v of being signaled to stop: killing it\n", gracePeriod)
+			_ = killProcessGroup(cmd)
+			killTimer = nil
 		}
-		return 0
 	}
 }
 
+// signalExitCode returns the conventional exit code for a process that was terminated by the provided signal.
+func signalExitCode(sig os.Signal) int {
+	if sysSig, ok := sig.(syscall.Signal); ok {
+		return 128 + int(sysSig)
+	}
+	return 1
+}
+
 func writeTempFile(pattern string, content []byte) (tmpFilePath string, rErr error) {
 	tmpFile, err := os.CreateTemp("", pattern)
 	if err != nil {
@@ -0,0 +1,117 @@
+// Copyright 2025 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunGolangCILintWithConfig(t *testing.T) {
+	for i, tc := range []struct {
+		name         string
+		script       string
+		timeout      time.Duration
+		interrupt    bool
+		env          []string
+		wantExitCode int
+		wantOutput   string
+	}{
+		{
+			name:         "exit code of golangci-lint is returned",
+			script:       "echo running; exit 1",
+			wantExitCode: 1,
+			wantOutput:   "running\n",
+		},
+		{
+			name:         "environment variables are added to the environment of golangci-lint",
+			script:       "echo \"$GOGC $GOMEMLIMIT\"",
+			env:          []string{"GOGC=50", "GOMEMLIMIT=1GiB"},
+			wantExitCode: 0,
+			wantOutput:   "50 1GiB\n",
+		},
+		{
+			name:         "golangci-lint is stopped when the timeout is exceeded",
+			script:       "sleep 30",
+			timeout:      100 * time.Millisecond,
+			wantExitCode: ExitCodeTimeout,
+			wantOutput:   "golangci-lint did not finish before the timeout was exceeded\n",
+		},
+		{
+			name: "processes started by golangci-lint are stopped when the timeout is exceeded",
+			// the child process inherits the output of the script, so the run only completes once it exits
+			script:       "sleep 30 & wait",
+			timeout:      100 * time.Millisecond,
+			wantExitCode: ExitCodeTimeout,
+			wantOutput:   "golangci-lint did not finish before the timeout was exceeded\n",
+		},
+		{
+			name:         "interrupt is forwarded to golangci-lint",
+			script:       "exec sleep 30",
+			interrupt:    true,
+			wantExitCode: 128 + int(syscall.SIGINT),
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s", i, tc.name), func(t *testing.T) {
+			dir := t.TempDir()
+			// the script records the path of the config file, which is the argument after "--config"
+			scriptPath := filepath.Join(dir, "golangci-lint")
+			script := fmt.Sprintf("#!/bin/sh\nwhile [ \"$1\" != \"--config\" ]; do shift; done\necho \"$2\" > %s\n%s\n", filepath.Join(dir, "config-path"), tc.script)
+			require.NoError(t, os.WriteFile(scriptPath, []byte(script), 0755))
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			if tc.interrupt {
+				go func() {
+					// wait for the script to start before interrupting the current process
+					for {
+						if _, err := os.Stat(filepath.Join(dir, "config-path")); err == nil {
+							break
+						}
+						time.Sleep(10 * time.Millisecond)
+					}
+					_ = syscall.Kill(os.Getpid(), syscall.SIGINT)
+				}()
+			}
+			start := time.Now()
+			var output bytes.Buffer
+			exitCode, err := RunGolangCILintWithConfig(ctx, scriptPath, dir, dir, tc.env, []string{"run"}, nil, []byte("version: \"2\"\n"), &output, &output, false)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantExitCode, exitCode)
+			assert.Equal(t, tc.wantOutput, output.String())
+			assert.Less(t, time.Since(start), gracePeriod)
+
+			configPath, err := os.ReadFile(filepath.Join(dir, "config-path"))
+			require.NoError(t, err)
+			assert.Equal(t, ConfigFilePath(dir, []byte("version: \"2\"\n")), strings.TrimSpace(string(configPath)))
+		})
+	}
+}